@@ -6,7 +6,8 @@ import (
 	"math"
 	"net/http"
 	"reflect"
-	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/user0608/goones/errs"
 )
@@ -18,6 +19,7 @@ type Response struct {
 	Type    string `json:"type,omitempty"` //error-response, success-response
 	Message string `json:"message,omitempty"`
 	Data    any    `json:"data,omitempty"`
+	Debug   string `json:"debug,omitempty"`
 }
 
 const success_response = "success"
@@ -47,46 +49,163 @@ func Updated(c Target) error { return c.JSON(http.StatusOK, &Response{Message: U
 
 func Deleted(c Target) error { return c.JSON(http.StatusOK, &Response{Message: DELETED}) }
 
+// ErrorItem describes one of the errors collected in an errs.List
+type ErrorItem struct {
+	Code    string `json:"code,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+var logCanceled atomic.Bool
+
+// Hook is called by Err with every error sent to a client, already translated, and its HTTP code
+type Hook func(err error, code int)
+
+var (
+	hooksMutex sync.RWMutex
+	hooks      []Hook
+)
+
+// AddHook registers a function called by Err with every error response
+func AddHook(hook Hook) {
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// LogCanceled enables logging of the requests canceled by the client,
+// which are not logged by default
+func LogCanceled(enabled bool) {
+	logCanceled.Store(enabled)
+}
+
+// UnwrapErr returns the HTTP code and the public message for err.
+// Only errs.Err messages reach the client; any other error text and the
+// internal detail of errs.Err are logged but never returned.
+// Other errors go through the errs.Translate chain; canceled contexts and
+// expired deadlines use errs.ErrCanceled and errs.ErrTimeout.
 func UnwrapErr(err error) (code int, message string) {
-	var werr *errs.Err
+	list, werr := resolve(err)
+	return unwrapErr(err, list, werr)
+}
+
+func unwrapErr(err error, list *errs.List, werr *errs.Err) (code int, message string) {
+	if list != nil {
+		skipCanceled := !logCanceled.Load()
+		go func(items []*errs.Err) {
+			for _, item := range items {
+				if skipCanceled && errs.IsCanceled(item) {
+					continue
+				}
+				if item.Wrapped() != nil {
+					slog.Error("internal error", errs.Attr(item))
+				}
+			}
+		}(list.Items())
+		return list.Code(), list.Message()
+	}
+
 	code = http.StatusInternalServerError
 	message = "Ocurrió un problema. Se produjo un error inesperado."
-	if errors.As(err, &werr) {
+	if werr != nil {
 		code = werr.Code()
 		message = werr.Message()
 	}
-	if werr == nil && err != nil {
-		var errSMS = strings.TrimSpace(err.Error())
-		if strings.HasPrefix(":", errSMS) {
-			code = http.StatusBadRequest
-			message = strings.TrimLeft(errSMS, ":")
-			return
-		}
+	if errs.IsCanceled(err) && !logCanceled.Load() {
+		return code, message
 	}
 	go func(err error, we *errs.Err) {
 		if we == nil && err != nil {
-			slog.Error("internal error", "error", err)
+			slog.Error("internal error", errs.Attr(err))
 			return
 		}
 		if we.Wrapped() != nil {
-			slog.Error("internal error", "error", we.Wrapped())
+			slog.Error("internal error", errs.Attr(we))
 			return
 		}
 	}(err, werr)
 	return code, message
 }
 
+// Err responds with the public representation of err. The error is translated
+// once and the hooks receive the translated *errs.Err when there is one.
 func Err(c Target, err error) error {
-	code, message := UnwrapErr(err)
-	return c.JSON(code, &Response{Type: error_message, Message: message})
+	list, werr := resolve(err)
+	code, message := unwrapErr(err, list, werr)
+
+	hooked := err
+	if werr != nil {
+		hooked = werr
+	}
+	hooksMutex.RLock()
+	registered := hooks
+	hooksMutex.RUnlock()
+	for _, hook := range registered {
+		hook(hooked, code)
+	}
+
+	response := &Response{Type: error_message, Message: message, Debug: errs.Debug(err)}
+	if list != nil {
+		response.Data = ErrorItems(list)
+	}
+	if werr != nil && (werr.Field() != "" || werr.ExposedValue() != "") {
+		response.Data = []ErrorItem{errorItem(werr)}
+	}
+	return c.JSON(code, response)
+}
+
+// resolve returns the list held by err or else its *errs.Err, translating
+// err when it is neither
+func resolve(err error) (*errs.List, *errs.Err) {
+	var list *errs.List
+	if errors.As(err, &list) {
+		return list, nil
+	}
+	var werr *errs.Err
+	if errors.As(err, &werr) {
+		return nil, werr
+	}
+	return nil, translate(err)
+}
+
+// translate converts an error that is not an *errs.Err with the errs
+// translation chain, then with the context errors. It returns nil when
+// neither matches.
+func translate(err error) *errs.Err {
+	if werr, ok := errs.Translate(err); ok {
+		return werr
+	}
+	werr, _ := errs.FromContext(err)
+	return werr
+}
+
+// ErrorItems returns the public representation of every error in list
+func ErrorItems(list *errs.List) []ErrorItem {
+	items := make([]ErrorItem, 0, list.Len())
+	for _, item := range list.Items() {
+		items = append(items, errorItem(item))
+	}
+	return items
+}
+
+func errorItem(err *errs.Err) ErrorItem {
+	return ErrorItem{
+		Code:    err.AppCode(),
+		Field:   err.Field(),
+		Value:   err.ExposedValue(),
+		Status:  err.Code(),
+		Message: err.Message(),
+	}
 }
 
 func JsonErr(c Target) error {
-	return Err(c, errs.BadRequestDirect(errs.ErrInvalidRequestBody))
+	return Err(c, errs.ErrInvalidRequestBody)
 }
 
 func QueryErr(c Target) error {
-	return Err(c, errs.BadRequestDirect(errs.ErrInvalidQueryParam))
+	return Err(c, errs.ErrInvalidQueryParam)
 }
 
 func Auto(c Target, err error) error {
@@ -0,0 +1,193 @@
+package answer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/user0608/goones/errs"
+)
+
+type recorder struct {
+	code int
+	body any
+}
+
+func (r *recorder) JSON(code int, i any) error {
+	r.code = code
+	r.body = i
+	return nil
+}
+
+func TestErrSingle(t *testing.T) {
+	rec := &recorder{}
+	if err := Err(rec, errs.NotFoundDirect("no existe")); err != nil {
+		t.Fatal(err)
+	}
+
+	response := rec.body.(*Response)
+	if rec.code != http.StatusNotFound || response.Message != "no existe" {
+		t.Errorf("Err() = %d %q, want 404 no existe", rec.code, response.Message)
+	}
+	if response.Data != nil {
+		t.Errorf("Data = %v, want nil", response.Data)
+	}
+}
+
+func TestErrList(t *testing.T) {
+	var list errs.List
+	list.Add(errs.BadRequestDirect("precio inválido"))
+	list.Add(errs.NewWithMessage(errs.ErrNotFound, "producto no existe"))
+
+	rec := &recorder{}
+	if err := Err(rec, list.Err()); err != nil {
+		t.Fatal(err)
+	}
+
+	response := rec.body.(*Response)
+	if rec.code != http.StatusNotFound {
+		t.Errorf("code = %v, want %v", rec.code, http.StatusNotFound)
+	}
+
+	items, ok := response.Data.([]ErrorItem)
+	if !ok || len(items) != 2 {
+		t.Fatalf("Data = %#v, want two items", response.Data)
+	}
+	if items[1].Code != "NOT_FOUND" || items[1].Status != http.StatusNotFound || items[1].Message != "producto no existe" {
+		t.Errorf("items[1] = %+v", items[1])
+	}
+}
+
+func TestErrHidesInternalText(t *testing.T) {
+	rec := &recorder{}
+	if err := Err(rec, errors.New(": dial tcp db.internal:5432: connection refused")); err != nil {
+		t.Fatal(err)
+	}
+
+	response := rec.body.(*Response)
+	if rec.code != http.StatusInternalServerError {
+		t.Errorf("code = %v, want %v", rec.code, http.StatusInternalServerError)
+	}
+	if strings.Contains(response.Message, "db.internal") {
+		t.Errorf("Message leaks internal text: %v", response.Message)
+	}
+	if !errs.IsDevmode() && response.Debug != "" {
+		t.Errorf("Debug = %v, want empty outside dev mode", response.Debug)
+	}
+}
+
+func TestErrSingleWithField(t *testing.T) {
+	translator := errs.NewPgTranslator()
+	translator.ExposeValues(true)
+
+	rec := &recorder{}
+	err := translator.Pgf(&pgconn.PgError{
+		Code:   string(errs.PgDuplicateRecordError),
+		Detail: "Key (email)=(a@b.com) already exists.",
+	})
+	if err := Err(rec, err); err != nil {
+		t.Fatal(err)
+	}
+
+	items, ok := rec.body.(*Response).Data.([]ErrorItem)
+	if !ok || len(items) != 1 {
+		t.Fatalf("Data = %#v, want one item", rec.body.(*Response).Data)
+	}
+	if items[0].Field != "email" || items[0].Value != "a@b.com" {
+		t.Errorf("items[0] = %+v, want email a@b.com", items[0])
+	}
+}
+
+func TestUnwrapErrContext(t *testing.T) {
+	code, message := UnwrapErr(fmt.Errorf("query: %w", context.Canceled))
+	if code != errs.StatusClientClosedRequest || message != errs.ErrCanceled.Message() {
+		t.Errorf("UnwrapErr() = %d %q", code, message)
+	}
+
+	code, _ = UnwrapErr(context.DeadlineExceeded)
+	if code != http.StatusGatewayTimeout {
+		t.Errorf("UnwrapErr() = %d, want 504", code)
+	}
+}
+
+func TestAddHook(t *testing.T) {
+	var gotErr error
+	var gotCode int
+	AddHook(func(err error, code int) {
+		gotErr, gotCode = err, code
+	})
+	t.Cleanup(func() { hooks = nil })
+
+	if err := Err(&recorder{}, errs.ErrNotFound); err != nil {
+		t.Fatal(err)
+	}
+	if gotErr != errs.ErrNotFound || gotCode != http.StatusNotFound {
+		t.Errorf("hook got %v %d", gotErr, gotCode)
+	}
+}
+
+var (
+	errJWT        = errors.New("token is expired")
+	errExternal   = errors.New("external service rejected")
+	externalCalls int
+)
+
+// The translators are registered once because the errs chain has no way to
+// remove them, so running the tests again does not grow it.
+func init() {
+	errs.RegisterTranslator(0, func(err error) (*errs.Err, bool) {
+		if errors.Is(err, errJWT) {
+			return errs.ErrInvalidToken, true
+		}
+		return nil, false
+	})
+	errs.RegisterTranslator(0, func(err error) (*errs.Err, bool) {
+		if errors.Is(err, errExternal) {
+			externalCalls++
+			return errs.ErrInvalidToken, true
+		}
+		return nil, false
+	})
+}
+
+func TestUnwrapErrTranslate(t *testing.T) {
+	code, message := UnwrapErr(fmt.Errorf("auth: %w", errJWT))
+	if code != http.StatusUnauthorized || message != errs.ErrInvalidToken.Message() {
+		t.Errorf("UnwrapErr() = %d %q", code, message)
+	}
+
+	code, _ = UnwrapErr(errors.New("other"))
+	if code != http.StatusInternalServerError {
+		t.Errorf("UnwrapErr() = %d, want 500", code)
+	}
+}
+
+func TestErrTranslatesOnce(t *testing.T) {
+	externalCalls = 0
+
+	var gotErr error
+	AddHook(func(err error, code int) {
+		gotErr = err
+	})
+	t.Cleanup(func() { hooks = nil })
+
+	rec := &recorder{}
+	if err := Err(rec, fmt.Errorf("call: %w", errExternal)); err != nil {
+		t.Fatal(err)
+	}
+	if externalCalls != 1 {
+		t.Errorf("translator called %d times, want 1", externalCalls)
+	}
+	if rec.code != http.StatusUnauthorized {
+		t.Errorf("code = %d, want %d", rec.code, http.StatusUnauthorized)
+	}
+
+	var werr *errs.Err
+	if !errors.As(gotErr, &werr) || werr.AppCode() != errs.ErrInvalidToken.AppCode() {
+		t.Errorf("hook got %v, want the translated error", gotErr)
+	}
+}
@@ -0,0 +1,93 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+var (
+	catalogMutex sync.RWMutex
+	catalog      = map[string]*Err{}
+)
+
+var (
+	ErrInvalidRequestBody          = Define("INVALID_REQUEST_BODY", http.StatusBadRequest, "La estructura de información enviada es inválida. Por favor, revise la documentación y vuelva a intentar.")
+	ErrInvalidQueryParam           = Define("INVALID_QUERY_PARAM", http.StatusBadRequest, "Los parámetros de consulta son inválidos. Favor de revisar la documentación y volver a intentar.")
+	ErrAuthorizationHeaderNotFound = Define("AUTHORIZATION_HEADER_NOT_FOUND", http.StatusUnauthorized, "La cabecera con el token de utilización no fue encontrada. La operación fue rechazada.")
+	ErrInvalidToken                = Define("INVALID_TOKEN", http.StatusUnauthorized, "El token que está utilizando no es válido o ha caducado. Contáctese con el equipo técnico.")
+	ErrSigningTokenString          = Define("SIGNING_TOKEN_STRING", http.StatusUnauthorized, "El token que está utilizando no es genuino. Contáctese con el equipo técnico.")
+	ErrDatabase                    = Define("DATABASE", http.StatusInternalServerError, "La operación no se pudo realizar debido a algún problema. Contáctese con el equipo técnico.")
+
+	ErrRecordNotFound        = Define("RECORD_NOT_FOUND", http.StatusBadRequest, "El registro buscado no fue encontrado.")
+	ErrCreating              = Define("CREATING", http.StatusInternalServerError, "No se pudo realizar la operación de registro.")
+	ErrUpdating              = Define("UPDATING", http.StatusInternalServerError, "No se pudo realizar la operación de actualización.")
+	ErrUserOrPasswordInvalid = Define("USER_OR_PASSWORD_INVALID", http.StatusUnauthorized, "Usuario o contraseña incorrectos.")
+	ErrIDNotFound            = Define("ID_NOT_FOUND", http.StatusBadRequest, "Parámetro ID o identificador no encontrado.")
+	ErrCodeNotFound          = Define("CODE_NOT_FOUND", http.StatusBadRequest, "Parámetro código no encontrado.")
+	ErrNameNotFound          = Define("NAME_NOT_FOUND", http.StatusBadRequest, "Parámetro nombre no encontrado.")
+	ErrNotFound              = Define("NOT_FOUND", http.StatusNotFound, "No se pudo encontrar ningún recurso asociado a esta consulta.")
+	ErrGeneric               = Define("GENERIC", http.StatusInternalServerError, "Hubo un error inesperado. Favor de reportar la incidencia al equipo técnico.")
+	ErrInternal              = ErrGeneric
+)
+
+// Define registers a sentinel error identified by code in the catalog.
+// Errors derived from it keep matching with errors.Is even when their message changes.
+// It panics if the code is empty or already defined.
+func Define(code string, httpCode int, message string) *Err {
+	if code == "" {
+		panic("errs: Define with empty code")
+	}
+
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	if _, exists := catalog[code]; exists {
+		panic(fmt.Sprintf("errs: code %q already defined", code))
+	}
+
+	sentinel := &Err{appCode: code, httpCode: httpCode, message: message}
+	sentinel.sentinel = sentinel
+	catalog[code] = sentinel
+	return sentinel
+}
+
+// Lookup returns the sentinel error registered with code
+func Lookup(code string) (*Err, bool) {
+	catalogMutex.RLock()
+	defer catalogMutex.RUnlock()
+
+	sentinel, ok := catalog[code]
+	return sentinel, ok
+}
+
+// Catalog returns every registered sentinel error sorted by code
+func Catalog() []*Err {
+	catalogMutex.RLock()
+	defer catalogMutex.RUnlock()
+
+	sentinels := make([]*Err, 0, len(catalog))
+	for _, sentinel := range catalog {
+		sentinels = append(sentinels, sentinel)
+	}
+	sort.Slice(sentinels, func(i, j int) bool {
+		return sentinels[i].appCode < sentinels[j].appCode
+	})
+	return sentinels
+}
+
+// Override replaces the HTTP code and message of a registered sentinel error.
+// It must be called during initialization, before the error is used.
+func Override(code string, httpCode int, message string) bool {
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	sentinel, ok := catalog[code]
+	if !ok {
+		return false
+	}
+	sentinel.httpCode = httpCode
+	sentinel.message = message
+	return true
+}
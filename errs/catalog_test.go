@@ -0,0 +1,150 @@
+package errs
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// restoreRegistries snapshots the package registries and restores them when
+// the test ends, so tests that Define, Override or Register something can run
+// more than once in the same process.
+func restoreRegistries(t *testing.T) {
+	t.Helper()
+
+	catalogMutex.RLock()
+	savedCatalog := maps.Clone(catalog)
+	savedSentinels := make(map[string]Err, len(catalog))
+	for code, sentinel := range catalog {
+		savedSentinels[code] = *sentinel
+	}
+	catalogMutex.RUnlock()
+
+	chainMutex.RLock()
+	savedTranslators := slices.Clone(translators)
+	chainMutex.RUnlock()
+
+	driversMutex.RLock()
+	savedAdapters := slices.Clone(driverAdapters)
+	savedNoRows := slices.Clone(noRowsErrs)
+	driversMutex.RUnlock()
+
+	precedenceMutex.RLock()
+	savedPrecedence := slices.Clone(statusPrecedence)
+	precedenceMutex.RUnlock()
+
+	t.Cleanup(func() {
+		catalogMutex.Lock()
+		catalog = savedCatalog
+		for code, sentinel := range catalog {
+			*sentinel = savedSentinels[code]
+		}
+		catalogMutex.Unlock()
+
+		chainMutex.Lock()
+		translators = savedTranslators
+		chainMutex.Unlock()
+
+		driversMutex.Lock()
+		driverAdapters, noRowsErrs = savedAdapters, savedNoRows
+		driversMutex.Unlock()
+
+		precedenceMutex.Lock()
+		statusPrecedence = savedPrecedence
+		precedenceMutex.Unlock()
+	})
+}
+
+func TestDefineMatchesAfterWrapping(t *testing.T) {
+	restoreRegistries(t)
+
+	sentinel := Define("TEST_DEFINE_MATCH", http.StatusConflict, "Conflicto")
+
+	t.Run("custom message keeps identity", func(t *testing.T) {
+		err := NewWithMessage(sentinel, "El cliente ya existe")
+		if !errors.Is(err, sentinel) {
+			t.Errorf("errors.Is() = false, want true")
+		}
+		if err.(*Err).Code() != http.StatusConflict {
+			t.Errorf("Code() = %v, want %v", err.(*Err).Code(), http.StatusConflict)
+		}
+		if err.(*Err).AppCode() != "TEST_DEFINE_MATCH" {
+			t.Errorf("AppCode() = %v, want TEST_DEFINE_MATCH", err.(*Err).AppCode())
+		}
+	})
+
+	t.Run("wrapped cause keeps identity", func(t *testing.T) {
+		err := fmt.Errorf("repository: %w", sentinel.Wrap(errors.New("driver error")))
+		if !errors.Is(err, sentinel) {
+			t.Errorf("errors.Is() = false, want true")
+		}
+	})
+
+	t.Run("different sentinel does not match", func(t *testing.T) {
+		if errors.Is(sentinel.Wrap(nil), ErrNotFound) {
+			t.Errorf("errors.Is() = true, want false")
+		}
+	})
+
+	t.Run("plain Err does not match", func(t *testing.T) {
+		if errors.Is(BadRequestDirect("Conflicto"), sentinel) {
+			t.Errorf("errors.Is() = true, want false")
+		}
+	})
+}
+
+func TestDefineDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for duplicated code")
+		}
+	}()
+	Define("RECORD_NOT_FOUND", http.StatusNotFound, "duplicated")
+}
+
+func TestLookupAndCatalog(t *testing.T) {
+	sentinel, ok := Lookup("RECORD_NOT_FOUND")
+	if !ok || sentinel != ErrRecordNotFound {
+		t.Fatalf("Lookup() = %v, %v, want ErrRecordNotFound", sentinel, ok)
+	}
+
+	if _, ok := Lookup("UNKNOWN_CODE"); ok {
+		t.Errorf("Lookup() found an undefined code")
+	}
+
+	items := Catalog()
+	for i := 1; i < len(items); i++ {
+		if items[i-1].AppCode() > items[i].AppCode() {
+			t.Fatalf("Catalog() not sorted: %s before %s", items[i-1].AppCode(), items[i].AppCode())
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	restoreRegistries(t)
+
+	sentinel := Define("TEST_OVERRIDE", http.StatusBadRequest, "original")
+
+	if !Override("TEST_OVERRIDE", http.StatusNotFound, "overridden") {
+		t.Fatal("Override() = false, want true")
+	}
+	if sentinel.Code() != http.StatusNotFound || sentinel.Message() != "overridden" {
+		t.Errorf("sentinel = %d %q, want 404 overridden", sentinel.Code(), sentinel.Message())
+	}
+	if Override("TEST_UNKNOWN", http.StatusNotFound, "x") {
+		t.Errorf("Override() = true for undefined code")
+	}
+}
+
+func TestPgfReturnsSentinels(t *testing.T) {
+	if err := Pgf(fmt.Errorf("repository: %w", sql.ErrNoRows)); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Pgf() = %v, want ErrRecordNotFound", err)
+	}
+	if err := Pgf(errors.New("connection refused")); !errors.Is(err, ErrDatabase) {
+		t.Errorf("Pgf() = %v, want ErrDatabase", err)
+	}
+}
@@ -0,0 +1,61 @@
+package errs
+
+import (
+	"slices"
+	"sync"
+)
+
+// TranslatorFunc converts an error of a third party library, such as a Redis,
+// S3, JWT or HTTP client error, into an *Err. It returns false when err is not
+// one of the errors it knows.
+type TranslatorFunc func(err error) (*Err, bool)
+
+type registeredTranslator struct {
+	priority  int
+	translate TranslatorFunc
+}
+
+var (
+	chainMutex  sync.RWMutex
+	translators []registeredTranslator
+)
+
+// RegisterTranslator adds fn to the translation chain. Translators with a higher
+// priority run first; those with the same priority run in registration order.
+func RegisterTranslator(priority int, fn TranslatorFunc) {
+	chainMutex.Lock()
+	defer chainMutex.Unlock()
+
+	index, _ := slices.BinarySearchFunc(translators, priority, func(t registeredTranslator, priority int) int {
+		if t.priority >= priority {
+			return -1
+		}
+		return 1
+	})
+	translators = slices.Insert(translators, index, registeredTranslator{priority, fn})
+}
+
+// Translate runs the translation chain and returns the first match.
+// The match wraps err when the translator does not set a cause, so the
+// original error still reaches the logs.
+func Translate(err error) (*Err, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	chainMutex.RLock()
+	chain := translators
+	chainMutex.RUnlock()
+
+	for _, t := range chain {
+		result, ok := t.translate(err)
+		if !ok || result == nil {
+			continue
+		}
+		if result.wrapped == nil {
+			return result.Wrap(err).(*Err), true
+		}
+		return result, true
+	}
+	return nil, false
+}
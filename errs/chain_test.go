@@ -0,0 +1,47 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	restoreRegistries(t)
+	translators = nil
+
+	errRedis := errors.New("redis: nil")
+	errTimeout := errors.New("i/o timeout")
+
+	var calls []string
+	match := func(name string, target error, result *Err) TranslatorFunc {
+		return func(err error) (*Err, bool) {
+			calls = append(calls, name)
+			if errors.Is(err, target) {
+				return result, true
+			}
+			return nil, false
+		}
+	}
+
+	RegisterTranslator(0, match("redis", errRedis, ErrNotFound))
+	RegisterTranslator(10, match("network", errTimeout, ErrTimeout))
+	RegisterTranslator(0, match("fallback", errRedis, ErrGeneric))
+
+	got, ok := Translate(errRedis)
+	if !ok || !errors.Is(got, ErrNotFound) {
+		t.Fatalf("Translate() = %v, %v, want ErrNotFound", got, ok)
+	}
+	if got.Wrapped() != errRedis {
+		t.Errorf("Wrapped() = %v, want the original error", got.Wrapped())
+	}
+	if len(calls) != 2 || calls[0] != "network" || calls[1] != "redis" {
+		t.Errorf("calls = %v, want [network redis]", calls)
+	}
+
+	if _, ok := Translate(errors.New("other")); ok {
+		t.Error("Translate() must not match unknown errors")
+	}
+	if _, ok := Translate(nil); ok {
+		t.Error("Translate(nil) must not match")
+	}
+}
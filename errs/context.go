@@ -0,0 +1,52 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// StatusClientClosedRequest is the non standard code used when the client
+// cancels the request before the operation finishes
+const StatusClientClosedRequest = 499
+
+const messageTimeout = "La operación excedió el tiempo máximo permitido."
+
+// ErrCanceled and ErrTimeout can be adjusted per application with Override
+var (
+	ErrCanceled = Define("CANCELED", StatusClientClosedRequest, "La solicitud fue cancelada antes de completarse.")
+	ErrTimeout  = Define("TIMEOUT", http.StatusGatewayTimeout, messageTimeout)
+)
+
+// IsCanceled reports whether err comes from a canceled context
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, ErrCanceled)
+}
+
+// IsTimeout reports whether err comes from an expired deadline or a
+// statement canceled by the database (57014)
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTimeout) || pgconn.Timeout(err) {
+		return true
+	}
+	return sqlState(err) == PgQueryCanceledError
+}
+
+// FromContext returns ErrCanceled or ErrTimeout wrapping err when err comes
+// from a canceled context or an expired deadline
+func FromContext(err error) (*Err, bool) {
+	switch {
+	case err == nil:
+		return nil, false
+	case errors.Is(err, context.Canceled):
+		return ErrCanceled.Wrap(err).(*Err), true
+	case errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err):
+		return ErrTimeout.Wrap(err).(*Err), true
+	}
+	return nil, false
+}
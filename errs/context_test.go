@@ -0,0 +1,69 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestPgfContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel *Err
+		code     int
+	}{
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), ErrCanceled, StatusClientClosedRequest},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), ErrTimeout, http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Pgf(tt.err)
+			if !errors.Is(got, tt.sentinel) {
+				t.Errorf("Pgf() = %v, want %v", got, tt.sentinel.AppCode())
+			}
+			if got.(*Err).Code() != tt.code {
+				t.Errorf("Code() = %v, want %v", got.(*Err).Code(), tt.code)
+			}
+		})
+	}
+}
+
+func TestPgfQueryCanceled(t *testing.T) {
+	got := Pgf(&pgconn.PgError{Code: string(PgQueryCanceledError)}).(*Err)
+	if got.Code() != http.StatusGatewayTimeout || got.Message() != messageTimeout {
+		t.Errorf("Pgf() = %d %q", got.Code(), got.Message())
+	}
+}
+
+func TestIsCanceledIsTimeout(t *testing.T) {
+	canceled := Pgf(context.Canceled)
+	if !IsCanceled(canceled) || IsTimeout(canceled) {
+		t.Error("canceled error must be canceled and not timeout")
+	}
+
+	if !IsTimeout(&pgconn.PgError{Code: string(PgQueryCanceledError)}) {
+		t.Error("57014 must be a timeout")
+	}
+	if !IsTimeout(Pgf(context.DeadlineExceeded)) {
+		t.Error("translated deadline must be a timeout")
+	}
+	if IsCanceled(errors.New("x")) || IsTimeout(errors.New("x")) || IsTimeout(nil) {
+		t.Error("plain errors must not match")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if _, ok := FromContext(errors.New("x")); ok {
+		t.Error("FromContext() must ignore other errors")
+	}
+	got, ok := FromContext(context.Canceled)
+	if !ok || !errors.Is(got, ErrCanceled) || !errors.Is(got, context.Canceled) {
+		t.Errorf("FromContext() = %v, %v", got, ok)
+	}
+}
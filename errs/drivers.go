@@ -0,0 +1,164 @@
+package errs
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DBError holds the diagnostic fields reported by a database driver.
+// Adapters of non Postgres drivers set SQLState to the equivalent Postgres code
+// so every driver shares the same translation table.
+type DBError struct {
+	Driver     string
+	Code       string
+	SQLState   string
+	Message    string
+	Detail     string
+	Constraint string
+	Table      string
+	Column     string
+	// MissingReference is set when a foreign key points to a row that does not exist
+	MissingReference bool
+}
+
+// DriverAdapter extracts the diagnostic fields from the errors of a database driver
+type DriverAdapter func(err error) (*DBError, bool)
+
+var (
+	driversMutex   sync.RWMutex
+	driverAdapters = []DriverAdapter{pgxAdapter, sqlStateAdapter}
+	noRowsErrs     = []error{pgx.ErrNoRows, sql.ErrNoRows}
+)
+
+// RegisterDriverAdapter adds an adapter that is tried before the built-in ones
+func RegisterDriverAdapter(adapter DriverAdapter) {
+	driversMutex.Lock()
+	defer driversMutex.Unlock()
+	driverAdapters = append([]DriverAdapter{adapter}, driverAdapters...)
+}
+
+// RegisterNoRows adds a sentinel error meaning that a query returned no rows.
+// pgx.ErrNoRows and sql.ErrNoRows are built in; GORM users register
+// gorm.ErrRecordNotFound:
+//
+//	errs.RegisterNoRows(gorm.ErrRecordNotFound)
+func RegisterNoRows(err error) {
+	driversMutex.Lock()
+	defer driversMutex.Unlock()
+	noRowsErrs = append(noRowsErrs, err)
+}
+
+// IsNoRows reports whether err matches any of the registered no rows errors
+func IsNoRows(err error) bool {
+	driversMutex.RLock()
+	defer driversMutex.RUnlock()
+	for _, target := range noRowsErrs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractDBError returns the diagnostic fields of the driver error wrapped by err
+func ExtractDBError(err error) (*DBError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	driversMutex.RLock()
+	defer driversMutex.RUnlock()
+	for _, adapter := range driverAdapters {
+		if dbErr, ok := adapter(err); ok {
+			if dbErr.Column == "" {
+				dbErr.Column, _, _, _ = parsePgDetail(dbErr.Detail)
+			}
+			return dbErr, true
+		}
+	}
+	return nil, false
+}
+
+func pgxAdapter(err error) (*DBError, bool) {
+	var pgerr *pgconn.PgError
+	if !errors.As(err, &pgerr) {
+		return nil, false
+	}
+	return &DBError{
+		Driver:     "postgres",
+		Code:       pgerr.Code,
+		SQLState:   pgerr.Code,
+		Message:    pgerr.Message,
+		Detail:     pgerr.Detail,
+		Constraint: pgerr.ConstraintName,
+		Table:      pgerr.TableName,
+		Column:     pgerr.ColumnName,
+
+		MissingReference: isMissingReference(pgerr.Code, pgerr.Message),
+	}, true
+}
+
+// sqlStateError is implemented by lib/pq and other drivers exposing the SQLSTATE code
+type sqlStateError interface {
+	error
+	SQLState() string
+}
+
+// fieldGetter is implemented by lib/pq to read the fields of the Postgres error protocol
+type fieldGetter interface {
+	Get(k byte) string
+}
+
+func sqlStateAdapter(err error) (*DBError, bool) {
+	stateErr, ok := findSQLStateError(err)
+	if !ok {
+		return nil, false
+	}
+	dbErr := &DBError{
+		Driver:   "postgres",
+		Code:     stateErr.SQLState(),
+		SQLState: stateErr.SQLState(),
+		Message:  stateErr.Error(),
+	}
+	if getter, ok := stateErr.(fieldGetter); ok {
+		dbErr.Message = getter.Get('M')
+		dbErr.Detail = getter.Get('D')
+		dbErr.Constraint = getter.Get('n')
+		dbErr.Table = getter.Get('t')
+		dbErr.Column = getter.Get('c')
+	}
+	dbErr.MissingReference = isMissingReference(dbErr.SQLState, dbErr.Message)
+	return dbErr, true
+}
+
+func isMissingReference(code string, message string) bool {
+	return PGCode(code) == PgDependentRecordsError && strings.Contains(message, "insert or update")
+}
+
+// findSQLStateError walks the chain of err like errors.As, skipping *Err
+// which also exposes SQLState but is not a driver error
+func findSQLStateError(err error) (sqlStateError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	if _, own := err.(*Err); !own {
+		if stateErr, ok := err.(sqlStateError); ok {
+			return stateErr, true
+		}
+	}
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		return findSQLStateError(wrapper.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapper.Unwrap() {
+			if stateErr, ok := findSQLStateError(inner); ok {
+				return stateErr, true
+			}
+		}
+	}
+	return nil, false
+}
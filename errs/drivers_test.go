@@ -0,0 +1,120 @@
+package errs
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pqError mimics the exported API of lib/pq errors
+type pqError struct {
+	fields map[byte]string
+}
+
+func (e *pqError) Error() string     { return "pq: " + e.fields['M'] }
+func (e *pqError) SQLState() string  { return e.fields['C'] }
+func (e *pqError) Get(k byte) string { return e.fields[k] }
+
+func TestExtractDBErrorPgx(t *testing.T) {
+	dbErr, ok := ExtractDBError(fmt.Errorf("insert: %w", &pgconn.PgError{
+		Code:           string(PgDuplicateRecordError),
+		ConstraintName: "clientes_email_key",
+		TableName:      "clientes",
+	}))
+
+	if !ok {
+		t.Fatal("ExtractDBError() = false, want true")
+	}
+	if dbErr.SQLState != string(PgDuplicateRecordError) || dbErr.Constraint != "clientes_email_key" || dbErr.Table != "clientes" {
+		t.Errorf("ExtractDBError() = %+v", dbErr)
+	}
+}
+
+func TestExtractDBErrorLibPq(t *testing.T) {
+	err := &pqError{fields: map[byte]string{
+		'C': string(PgDependentRecordsError),
+		'M': "update or delete on table violates foreign key constraint",
+		'D': `Key (id)=(1) is still referenced from table "ventas".`,
+		'n': "ventas_cliente_id_fkey",
+		't': "clientes",
+	}}
+
+	dbErr, ok := ExtractDBError(err)
+	if !ok {
+		t.Fatal("ExtractDBError() = false, want true")
+	}
+	if dbErr.SQLState != string(PgDependentRecordsError) || dbErr.Constraint != "ventas_cliente_id_fkey" || dbErr.Detail == "" {
+		t.Errorf("ExtractDBError() = %+v", dbErr)
+	}
+
+	got := Pgf(err).(*Err)
+	if got.SQLState() != string(PgDependentRecordsError) || got.Code() != http.StatusBadRequest {
+		t.Errorf("Pgf() = %v, want translated lib/pq error", got)
+	}
+	if !IsPgErrCode(err, PgDependentRecordsError) {
+		t.Errorf("IsPgErrCode() = false, want true")
+	}
+}
+
+func TestExtractDBErrorIgnoresErr(t *testing.T) {
+	if _, ok := ExtractDBError(BadRequestDirect("bad request")); ok {
+		t.Errorf("ExtractDBError() = true for *Err without driver error")
+	}
+}
+
+func TestRegisterDriverAdapter(t *testing.T) {
+	restoreRegistries(t)
+
+	type customDriverErr struct{ error }
+
+	RegisterDriverAdapter(func(err error) (*DBError, bool) {
+		var custom customDriverErr
+		if errors.As(err, &custom) {
+			return &DBError{SQLState: string(PgInvalidLengthError)}, true
+		}
+		return nil, false
+	})
+
+	got := Pgf(customDriverErr{errors.New("value too long")}).(*Err)
+	if got.SQLState() != string(PgInvalidLengthError) {
+		t.Errorf("SQLState() = %v, want %v", got.SQLState(), PgInvalidLengthError)
+	}
+}
+
+func TestIsNoRows(t *testing.T) {
+	restoreRegistries(t)
+
+	gormNotFound := errors.New("record not found")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"pgx", pgx.ErrNoRows, true},
+		{"database/sql", fmt.Errorf("find: %w", sql.ErrNoRows), true},
+		{"unregistered message", errors.New("record not found"), false},
+		{"plain", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNoRows(tt.err); got != tt.want {
+				t.Errorf("IsNoRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	RegisterNoRows(gormNotFound)
+	if !IsNoRows(fmt.Errorf("find: %w", gormNotFound)) {
+		t.Errorf("IsNoRows() = false for registered error")
+	}
+	if !errors.Is(Pgf(gormNotFound), ErrRecordNotFound) {
+		t.Errorf("Pgf() did not translate registered no rows error")
+	}
+}
@@ -0,0 +1,165 @@
+// Package errmetrics counts the errors translated by errs and returned by answer
+// and exposes them in the Prometheus text format.
+//
+//	collector := errmetrics.NewCollector()
+//	errs.DefaultPgTranslator().AddHook(collector.TranslateHook())
+//	answer.AddHook(collector.AnswerHook())
+//	admin.GET("/metrics", echo.WrapHandler(collector))
+package errmetrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/user0608/goones/errs"
+)
+
+// MetricName is the name of the counter written by ServeHTTP
+const MetricName = "goones_errors_total"
+
+// Sources of the observed errors
+const (
+	SourceAnswer    = "answer"
+	SourceTranslate = "translate"
+)
+
+// Key identifies a counter
+type Key struct {
+	Source   string
+	Status   int
+	SQLState string
+	AppCode  string
+}
+
+// Sample is the value of a counter at the time of a Snapshot
+type Sample struct {
+	Key
+	Count uint64
+}
+
+// Collector keeps error counters in memory. It is safe for concurrent use.
+type Collector struct {
+	mu     sync.Mutex
+	counts map[Key]uint64
+}
+
+func NewCollector() *Collector {
+	return &Collector{counts: map[Key]uint64{}}
+}
+
+// Observe counts err under source. Every item of an errs.List is counted
+// on its own and errors that are not *errs.Err are counted with status.
+func (c *Collector) Observe(source string, err error, status int) {
+	if err == nil {
+		return
+	}
+
+	var list *errs.List
+	if errors.As(err, &list) {
+		for _, item := range list.Items() {
+			c.add(keyOf(source, item, item.Code()))
+		}
+		return
+	}
+
+	var customErr *errs.Err
+	if errors.As(err, &customErr) {
+		c.add(keyOf(source, customErr, status))
+		return
+	}
+	c.add(Key{Source: source, Status: status})
+}
+
+func keyOf(source string, err *errs.Err, status int) Key {
+	return Key{Source: source, Status: status, SQLState: err.SQLState(), AppCode: err.AppCode()}
+}
+
+func (c *Collector) add(key Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[key]++
+}
+
+// TranslateHook returns a hook for errs.PgTranslator.AddHook
+func (c *Collector) TranslateHook() errs.TranslateHook {
+	return func(_ error, translated *errs.Err) {
+		c.Observe(SourceTranslate, translated, translated.Code())
+	}
+}
+
+// AnswerHook returns a hook for answer.AddHook
+func (c *Collector) AnswerHook() func(err error, code int) {
+	return func(err error, code int) {
+		c.Observe(SourceAnswer, err, code)
+	}
+}
+
+// Snapshot returns the current counters sorted by source, status, SQLSTATE and application code
+func (c *Collector) Snapshot() []Sample {
+	c.mu.Lock()
+	samples := make([]Sample, 0, len(c.counts))
+	for key, count := range c.counts {
+		samples = append(samples, Sample{Key: key, Count: count})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i].Key, samples[j].Key
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		if a.SQLState != b.SQLState {
+			return a.SQLState < b.SQLState
+		}
+		return a.AppCode < b.AppCode
+	})
+	return samples
+}
+
+// Count returns the value of the counter identified by key
+func (c *Collector) Count(key Key) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[key]
+}
+
+// Reset sets every counter to zero
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.counts)
+}
+
+// ServeHTTP writes the counters in the Prometheus text exposition format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	var b strings.Builder
+	b.WriteString("# HELP " + MetricName + " Errors translated by errs and returned by answer.\n")
+	b.WriteString("# TYPE " + MetricName + " counter\n")
+	for _, sample := range c.Snapshot() {
+		fmt.Fprintf(&b, "%s{source=%s,status=%s,sqlstate=%s,app_code=%s} %d\n",
+			MetricName,
+			quote(sample.Source),
+			quote(strconv.Itoa(sample.Status)),
+			quote(sample.SQLState),
+			quote(sample.AppCode),
+			sample.Count,
+		)
+	}
+	_, _ = w.Write([]byte(b.String()))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
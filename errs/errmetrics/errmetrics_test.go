@@ -0,0 +1,82 @@
+package errmetrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/user0608/goones/errs"
+)
+
+func TestTranslateHook(t *testing.T) {
+	collector := NewCollector()
+	translator := errs.NewPgTranslator()
+	translator.AddHook(collector.TranslateHook())
+
+	duplicate := &pgconn.PgError{Code: string(errs.PgDuplicateRecordError)}
+	_ = translator.Pgf(duplicate)
+	_ = translator.Pgf(duplicate)
+
+	key := Key{Source: SourceTranslate, Status: http.StatusBadRequest, SQLState: string(errs.PgDuplicateRecordError)}
+	if got := collector.Count(key); got != 2 {
+		t.Errorf("Count() = %d, want 2", got)
+	}
+}
+
+func TestAnswerHook(t *testing.T) {
+	collector := NewCollector()
+	hook := collector.AnswerHook()
+
+	var list errs.List
+	list.Add(errs.ErrNotFound)
+	list.Add(errs.ErrInvalidQueryParam)
+	hook(list.Err(), list.Code())
+	hook(errors.New("boom"), http.StatusInternalServerError)
+
+	samples := collector.Snapshot()
+	want := []Sample{
+		{Key{SourceAnswer, http.StatusBadRequest, "", "INVALID_QUERY_PARAM"}, 1},
+		{Key{SourceAnswer, http.StatusNotFound, "", "NOT_FOUND"}, 1},
+		{Key{SourceAnswer, http.StatusInternalServerError, "", ""}, 1},
+	}
+	if len(samples) != len(want) {
+		t.Fatalf("Snapshot() = %+v", samples)
+	}
+	for i := range want {
+		if samples[i] != want[i] {
+			t.Errorf("samples[%d] = %+v, want %+v", i, samples[i], want[i])
+		}
+	}
+
+	collector.Reset()
+	if len(collector.Snapshot()) != 0 {
+		t.Error("Reset() must clear the counters")
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	collector := NewCollector()
+	collector.Observe(SourceAnswer, errs.ErrNotFound, http.StatusNotFound)
+	collector.Observe(SourceAnswer, errs.ErrNotFound, http.StatusNotFound)
+
+	rec := httptest.NewRecorder()
+	collector.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	line := `goones_errors_total{source="answer",status="404",sqlstate="",app_code="NOT_FOUND"} 2`
+	if !strings.Contains(body, "# TYPE goones_errors_total counter\n") || !strings.Contains(body, line) {
+		t.Errorf("body = %q", body)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got := quote("a\"b\\c\nd"); got != `"a\"b\\c\nd"` {
+		t.Errorf("quote() = %s", got)
+	}
+}
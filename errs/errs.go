@@ -3,27 +3,53 @@ package errs
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 )
 
 type Err struct {
-	httpCode int
-	wrapped  error
-	message  string
+	httpCode   int
+	wrapped    error
+	message    string
+	detail     string
+	sqlState   string
+	constraint string
+	table      string
+	field      string
+	column     string
+	value      string
+	refTable   string
+	exposed    bool
+	appCode    string
+	sentinel   *Err
 }
 
 func (err *Err) Error() string {
-	if err.wrapped == nil {
-		return fmt.Sprintf("error: %s", err.message)
+	var b strings.Builder
+	b.WriteString("error: ")
+	b.WriteString(err.message)
+	if err.detail != "" {
+		b.WriteString("; detail: ")
+		b.WriteString(err.detail)
 	}
-	return fmt.Sprintf("error: %s; wrapped: %s", err.message, err.wrapped.Error())
+	if err.wrapped != nil {
+		b.WriteString("; wrapped: ")
+		b.WriteString(err.wrapped.Error())
+	}
+	return b.String()
 }
 
+// Message returns the public message, the only text that may be shown to clients
 func (err *Err) Message() string {
 	return err.message
 }
 
+// Detail returns the internal diagnostic text, which must never be shown to clients
+func (err *Err) Detail() string {
+	return err.detail
+}
+
 func (err *Err) Code() int {
 	return err.httpCode
 }
@@ -32,14 +58,127 @@ func (err *Err) Wrapped() error {
 	return err.wrapped
 }
 
+func (err *Err) Unwrap() error {
+	return err.wrapped
+}
+
+// AppCode returns the application code of the sentinel error this error derives from, if any
+func (err *Err) AppCode() string {
+	return err.appCode
+}
+
+// Is reports whether target is the sentinel error this error derives from
+func (err *Err) Is(target error) bool {
+	t, ok := target.(*Err)
+	return ok && t.sentinel == t && err.sentinel == t
+}
+
+// Wrap returns a copy of err that wraps cause, keeping its code and message
+func (err *Err) Wrap(cause error) error {
+	copied := *err
+	copied.wrapped = cause
+	return &copied
+}
+
+// SQLState returns the Postgres error code that originated the error, if any
+func (err *Err) SQLState() string {
+	return err.sqlState
+}
+
+// Constraint returns the name of the violated Postgres constraint, if any
+func (err *Err) Constraint() string {
+	return err.constraint
+}
+
+// Table returns the Postgres table involved in the error, if any
+func (err *Err) Table() string {
+	return err.table
+}
+
+// Field returns the name of the input field the error refers to, if any
+func (err *Err) Field() string {
+	return err.field
+}
+
+// Column returns the database column reported by the error, if any
+func (err *Err) Column() string {
+	return err.column
+}
+
+// Value returns the offending value reported by the database, if any.
+// It is internal data, see ExposedValue for the value that may reach clients.
+func (err *Err) Value() string {
+	return err.value
+}
+
+// ReferencedTable returns the table referenced by a violated foreign key, if any
+func (err *Err) ReferencedTable() string {
+	return err.refTable
+}
+
+// ExposedValue returns the offending value only when its exposure to clients was enabled
+func (err *Err) ExposedValue() string {
+	if !err.exposed {
+		return ""
+	}
+	return err.value
+}
+
+// LogValue implements slog.LogValuer so the error is logged as a group of attributes
+func (err *Err) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("code", err.httpCode),
+		slog.String("message", err.message),
+	}
+	if err.detail != "" {
+		attrs = append(attrs, slog.String("detail", err.detail))
+	}
+	if err.appCode != "" {
+		attrs = append(attrs, slog.String("app_code", err.appCode))
+	}
+	if err.wrapped != nil {
+		attrs = append(attrs, slog.String("cause", err.wrapped.Error()))
+	}
+	if err.sqlState != "" {
+		attrs = append(attrs, slog.String("sqlstate", err.sqlState))
+	}
+	if err.constraint != "" {
+		attrs = append(attrs, slog.String("constraint", err.constraint))
+	}
+	if err.table != "" {
+		attrs = append(attrs, slog.String("table", err.table))
+	}
+	if err.field != "" {
+		attrs = append(attrs, slog.String("field", err.field))
+	}
+	if err.column != "" {
+		attrs = append(attrs, slog.String("column", err.column))
+	}
+	if err.value != "" {
+		attrs = append(attrs, slog.String("value", err.value))
+	}
+	if err.refTable != "" {
+		attrs = append(attrs, slog.String("referenced_table", err.refTable))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Attr returns a slog attribute for err, using the structured form when err wraps an *Err
+func Attr(err error) slog.Attr {
+	var customErr *Err
+	if errors.As(err, &customErr) {
+		return slog.Any("error", customErr)
+	}
+	return slog.Any("error", err)
+}
+
 func newError(err error, message string, httpCode int) error {
 	var e *Err
 	if errors.As(err, &e) {
-		return &Err{
-			wrapped:  e.wrapped,
-			message:  message,
-			httpCode: httpCode,
-		}
+		copied := *e
+		copied.message = message
+		copied.httpCode = httpCode
+		return &copied
 	}
 
 	return &Err{
@@ -49,6 +188,29 @@ func newError(err error, message string, httpCode int) error {
 	}
 }
 
+// WithDetail attaches internal diagnostic text to err, keeping its public message.
+// Errors that are not of type Err are wrapped as ErrGeneric.
+func WithDetail(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	var customErr *Err
+	if !errors.As(err, &customErr) {
+		customErr = ErrGeneric.Wrap(err).(*Err)
+	}
+	copied := *customErr
+	copied.detail = fmt.Sprintf(format, args...)
+	return &copied
+}
+
+// Debug returns the full internal text of err when dev mode is enabled, otherwise an empty string
+func Debug(err error) string {
+	if err == nil || !IsDevmode() {
+		return ""
+	}
+	return err.Error()
+}
+
 // ContainsMessage checks if the message exists in the wrapped error message
 func ContainsMessage(err error, message string) bool {
 	var customErr *Err
@@ -62,11 +224,7 @@ func ContainsMessage(err error, message string) bool {
 func NewWithMessage(err error, message string) error {
 	var customErr *Err
 	if errors.As(err, &customErr) {
-		return &Err{
-			httpCode: customErr.httpCode,
-			wrapped:  customErr.wrapped,
-			message:  message,
-		}
+		return newError(customErr, message, customErr.httpCode)
 	}
 	return newError(err, message, http.StatusBadRequest)
 }
@@ -1,7 +1,10 @@
 package errs
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"testing"
 )
@@ -148,3 +151,95 @@ func TestWrapError(t *testing.T) {
 		}
 	})
 }
+
+func TestErr_LogValue(t *testing.T) {
+	err := &Err{
+		httpCode:   http.StatusBadRequest,
+		message:    "Test error",
+		wrapped:    fmt.Errorf("wrapped error"),
+		sqlState:   "23505",
+		constraint: "clientes_email_key",
+		table:      "clientes",
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("failed", Attr(fmt.Errorf("context: %w", err)))
+
+	var entry struct {
+		Error map[string]any `json:"error"`
+	}
+	if jerr := json.Unmarshal(buf.Bytes(), &entry); jerr != nil {
+		t.Fatalf("invalid JSON log entry: %v", jerr)
+	}
+
+	want := map[string]any{
+		"code":       float64(http.StatusBadRequest),
+		"message":    "Test error",
+		"cause":      "wrapped error",
+		"sqlstate":   "23505",
+		"constraint": "clientes_email_key",
+		"table":      "clientes",
+	}
+	for key, value := range want {
+		if entry.Error[key] != value {
+			t.Errorf("log attribute %s = %v, want %v", key, entry.Error[key], value)
+		}
+	}
+}
+
+func TestAttrNonErr(t *testing.T) {
+	attr := Attr(fmt.Errorf("plain error"))
+	if attr.Key != "error" {
+		t.Errorf("Attr().Key = %v, want error", attr.Key)
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		t.Errorf("Attr() returned a group for a plain error")
+	}
+}
+
+func TestWithDetail(t *testing.T) {
+	err := WithDetail(NotFoundDirect("Cliente no encontrado"), "select * from clientes where doc=%s", "123")
+
+	customErr := err.(*Err)
+	if customErr.Message() != "Cliente no encontrado" {
+		t.Errorf("Message() = %v, want Cliente no encontrado", customErr.Message())
+	}
+	if customErr.Detail() != "select * from clientes where doc=123" {
+		t.Errorf("Detail() = %v", customErr.Detail())
+	}
+	if got, want := customErr.Error(), "error: Cliente no encontrado; detail: select * from clientes where doc=123"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+
+	t.Run("plain error becomes generic", func(t *testing.T) {
+		err := WithDetail(fmt.Errorf("dial tcp db.internal:5432"), "connecting")
+		if err.(*Err).Message() != ErrGeneric.Message() {
+			t.Errorf("Message() = %v, want generic message", err.(*Err).Message())
+		}
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		if WithDetail(nil, "detail") != nil {
+			t.Errorf("expected nil")
+		}
+	})
+}
+
+func TestDebug(t *testing.T) {
+	previous := defaultTranslator
+	defaultTranslator = previous.Clone()
+	defer func() { defaultTranslator = previous }()
+
+	err := WithDetail(BadRequestDirect("Datos inválidos"), "constraint clientes_email_key")
+
+	defaultTranslator.devmode = false
+	if got := Debug(err); got != "" {
+		t.Errorf("Debug() = %v, want empty outside dev mode", got)
+	}
+
+	defaultTranslator.Devmode()
+	if got := Debug(err); got != err.Error() {
+		t.Errorf("Debug() = %v, want %v", got, err.Error())
+	}
+}
@@ -0,0 +1,252 @@
+package grpcerrs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/user0608/goones/errs"
+	"github.com/user0608/goones/kcheck"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain identifies the ErrorInfo details produced by this package
+const Domain = "goones.errs"
+
+// Code returns the gRPC code for err.
+// Postgres codes and sentinel errors refine the mapping derived from the HTTP code.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return codes.Canceled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return codes.DeadlineExceeded
+	}
+
+	var customErr *errs.Err
+	if !errors.As(err, &customErr) {
+		var fieldErrs kcheck.Errors
+		if errors.As(err, &fieldErrs) {
+			return codes.InvalidArgument
+		}
+		return codes.Internal
+	}
+
+	switch errs.PGCode(customErr.SQLState()) {
+	case errs.PgDuplicateRecordError:
+		return codes.AlreadyExists
+	case errs.PgDependentRecordsError:
+		return codes.FailedPrecondition
+	case errs.PgSerializationFailureError, errs.PgDeadlockDetectedError, errs.PgLockNotAvailableError:
+		return codes.Aborted
+	}
+
+	if errors.Is(err, errs.ErrRecordNotFound) || errors.Is(err, errs.ErrNotFound) {
+		return codes.NotFound
+	}
+
+	return FromHTTPStatus(customErr.Code())
+}
+
+// FromHTTPStatus returns the gRPC code equivalent to an HTTP status code
+func FromHTTPStatus(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return codes.OK
+	case http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+
+	if httpCode >= 400 && httpCode < 500 {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// HTTPStatus returns the HTTP status code equivalent to a gRPC code
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Status converts err into a gRPC status.
+// The application code and Postgres diagnostics travel as ErrorInfo and
+// kcheck field errors as BadRequest field violations.
+func Status(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	var customErr *errs.Err
+	if !errors.As(err, &customErr) {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+	}
+
+	code := Code(err)
+	message := errs.ErrGeneric.Message()
+	if customErr != nil {
+		message = customErr.Message()
+	}
+
+	var fieldErrs kcheck.Errors
+	if customErr == nil && errors.As(err, &fieldErrs) {
+		message = errs.ErrInvalidRequestBody.Message()
+	}
+
+	st := status.New(code, message)
+
+	var details []protoadapt.MessageV1
+	if customErr != nil && (customErr.AppCode() != "" || customErr.SQLState() != "") {
+		info := &errdetails.ErrorInfo{Reason: customErr.AppCode(), Domain: Domain}
+		if customErr.SQLState() != "" {
+			info.Metadata = map[string]string{"sqlstate": customErr.SQLState()}
+		}
+		details = append(details, info)
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	if customErr != nil && customErr.Field() != "" {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       customErr.Field(),
+			Description: customErr.Message(),
+		})
+	}
+	if errors.As(err, &fieldErrs) {
+		for _, item := range fieldErrs.Items {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       item.Field,
+				Description: item.Message,
+				Reason:      item.Code,
+			})
+		}
+	}
+	if len(badRequest.FieldViolations) > 0 {
+		details = append(details, badRequest)
+	}
+
+	if len(details) == 0 {
+		return st
+	}
+
+	withDetails, derr := st.WithDetails(details...)
+	if derr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// Error converts err into an error ready to be returned from a gRPC handler
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return Status(err).Err()
+}
+
+// FromStatus converts a gRPC status into an *errs.Err.
+// Known application codes resolve to their sentinel errors and field
+// violations are wrapped as kcheck.Errors.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var cause error = st.Err()
+	var sentinel *errs.Err
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() != Domain {
+				continue
+			}
+			if found, ok := errs.Lookup(d.GetReason()); ok {
+				sentinel = found
+			}
+		case *errdetails.BadRequest:
+			var fieldErrs kcheck.Errors
+			for _, violation := range d.GetFieldViolations() {
+				fieldErrs.Items = append(fieldErrs.Items, kcheck.FieldError{
+					Field:   violation.GetField(),
+					Message: violation.GetDescription(),
+					Code:    violation.GetReason(),
+				})
+			}
+			if len(fieldErrs.Items) > 0 {
+				cause = fieldErrs
+			}
+		}
+	}
+
+	if sentinel != nil {
+		return errs.NewWithMessage(sentinel.Wrap(cause), st.Message())
+	}
+	return errs.WrapError(cause, st.Message(), HTTPStatus(st.Code()))
+}
+
+// FromError converts an error returned by a gRPC client into an *errs.Err
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return FromStatus(st)
+}
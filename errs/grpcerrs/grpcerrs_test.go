@@ -0,0 +1,129 @@
+package grpcerrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/user0608/goones/errs"
+	"github.com/user0608/goones/kcheck"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"duplicate record", errs.Pgf(&pgconn.PgError{Code: string(errs.PgDuplicateRecordError)}), codes.AlreadyExists},
+		{"dependent records", errs.Pgf(&pgconn.PgError{Code: string(errs.PgDependentRecordsError)}), codes.FailedPrecondition},
+		{"invalid length", errs.Pgf(&pgconn.PgError{Code: string(errs.PgInvalidLengthError)}), codes.InvalidArgument},
+		{"deadlock", errs.Pgf(&pgconn.PgError{Code: string(errs.PgDeadlockDetectedError)}), codes.Aborted},
+		{"connection exception", errs.Pgf(&pgconn.PgError{Code: "08001"}), codes.Unavailable},
+		{"record not found", errs.Pgf(pgx.ErrNoRows), codes.NotFound},
+		{"unknown database error", errs.Pgf(errors.New("connection refused")), codes.Internal},
+		{"unauthorized", errs.UnauthorizedDirect("no"), codes.Unauthenticated},
+		{"forbidden", errs.ForbiddenDirect("no"), codes.PermissionDenied},
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), codes.Canceled},
+		{"kcheck errors", kcheck.Errors{Items: []kcheck.FieldError{{Field: "Name", Message: "required"}}}, codes.InvalidArgument},
+		{"plain error", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.err); got != tt.want {
+				t.Errorf("Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusDetails(t *testing.T) {
+	var fieldErrs kcheck.Errors
+	fieldErrs.Add("Email", "el campo [Email] no es un correo válido")
+
+	st := Status(errs.NewWithMessage(errs.ErrInvalidRequestBody.Wrap(fieldErrs), "Datos inválidos"))
+
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("Code() = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	if st.Message() != "Datos inválidos" {
+		t.Errorf("Message() = %v, want Datos inválidos", st.Message())
+	}
+
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+
+	if info == nil || info.GetReason() != "INVALID_REQUEST_BODY" {
+		t.Errorf("ErrorInfo = %v, want reason INVALID_REQUEST_BODY", info)
+	}
+	if badRequest == nil || len(badRequest.GetFieldViolations()) != 1 || badRequest.GetFieldViolations()[0].GetField() != "Email" {
+		t.Errorf("BadRequest = %v, want one Email violation", badRequest)
+	}
+}
+
+func TestStatusHidesInternalText(t *testing.T) {
+	st := Status(errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+	if st.Message() != errs.ErrGeneric.Message() {
+		t.Errorf("Message() = %v, want generic message", st.Message())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := errs.NewWithMessage(errs.ErrRecordNotFound, "El cliente no existe.")
+
+	got := FromError(Error(original))
+
+	var customErr *errs.Err
+	if !errors.As(got, &customErr) {
+		t.Fatalf("FromError() = %T, want *errs.Err", got)
+	}
+	if !errors.Is(got, errs.ErrRecordNotFound) {
+		t.Errorf("errors.Is(ErrRecordNotFound) = false, want true")
+	}
+	if customErr.Message() != "El cliente no existe." {
+		t.Errorf("Message() = %v, want El cliente no existe.", customErr.Message())
+	}
+}
+
+func TestFromStatusFieldViolations(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "Datos inválidos").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "Name", Description: "requerido", Reason: "REQUIRED"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := FromStatus(st)
+
+	if got.(*errs.Err).Code() != http.StatusBadRequest {
+		t.Errorf("Code() = %v, want %v", got.(*errs.Err).Code(), http.StatusBadRequest)
+	}
+
+	var fieldErrs kcheck.Errors
+	if !errors.As(got, &fieldErrs) || fieldErrs.Items[0].Field != "Name" || fieldErrs.Items[0].Code != "REQUIRED" {
+		t.Errorf("expected kcheck.Errors with Name, got %v", got)
+	}
+}
+
+func TestFromStatusOK(t *testing.T) {
+	if got := FromStatus(status.New(codes.OK, "")); got != nil {
+		t.Errorf("FromStatus() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,128 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var (
+	precedenceMutex  sync.RWMutex
+	statusPrecedence = []int{
+		http.StatusInternalServerError,
+		http.StatusServiceUnavailable,
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusConflict,
+		http.StatusBadRequest,
+	}
+)
+
+// SetStatusPrecedence sets the order in which HTTP codes win when a List computes its code.
+// Codes not listed lose against listed ones and are compared by value among themselves.
+func SetStatusPrecedence(httpCodes ...int) {
+	precedenceMutex.Lock()
+	defer precedenceMutex.Unlock()
+	statusPrecedence = append([]int(nil), httpCodes...)
+}
+
+func precedenceRank(httpCode int) int {
+	precedenceMutex.RLock()
+	defer precedenceMutex.RUnlock()
+	for i, code := range statusPrecedence {
+		if code == httpCode {
+			return i
+		}
+	}
+	return len(statusPrecedence)
+}
+
+// List collects several *Err values produced by a single operation
+type List struct {
+	items []*Err
+}
+
+// Add appends err to the list. Nil errors are ignored, nested lists are
+// flattened and errors that are not *Err are wrapped as ErrGeneric.
+func (l *List) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	var list *List
+	if errors.As(err, &list) {
+		l.items = append(l.items, list.items...)
+		return
+	}
+
+	var customErr *Err
+	if errors.As(err, &customErr) {
+		l.items = append(l.items, customErr)
+		return
+	}
+
+	l.items = append(l.items, ErrGeneric.Wrap(err).(*Err))
+}
+
+func (l *List) Len() int {
+	return len(l.items)
+}
+
+func (l *List) Items() []*Err {
+	return l.items
+}
+
+// Err returns nil when the list is empty, otherwise the list itself
+func (l *List) Err() error {
+	if len(l.items) == 0 {
+		return nil
+	}
+	return l
+}
+
+func (l *List) Error() string {
+	messages := make([]string, len(l.items))
+	for i, item := range l.items {
+		messages[i] = item.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (l *List) Unwrap() []error {
+	wrapped := make([]error, len(l.items))
+	for i, item := range l.items {
+		wrapped[i] = item
+	}
+	return wrapped
+}
+
+// Code returns the HTTP code of the list according to the status precedence
+func (l *List) Code() int {
+	if lead := l.lead(); lead != nil {
+		return lead.httpCode
+	}
+	return http.StatusInternalServerError
+}
+
+// Message returns the message of the error that determines the list code
+func (l *List) Message() string {
+	if lead := l.lead(); lead != nil {
+		return lead.message
+	}
+	return ""
+}
+
+func (l *List) lead() *Err {
+	var lead *Err
+	leadRank := 0
+	for _, item := range l.items {
+		rank := precedenceRank(item.httpCode)
+		if lead == nil || rank < leadRank || (rank == leadRank && item.httpCode > lead.httpCode) {
+			lead = item
+			leadRank = rank
+		}
+	}
+	return lead
+}
@@ -0,0 +1,79 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestListEmpty(t *testing.T) {
+	var list List
+	list.Add(nil)
+
+	if list.Err() != nil {
+		t.Errorf("Err() = %v, want nil", list.Err())
+	}
+}
+
+func TestListAdd(t *testing.T) {
+	var nested List
+	nested.Add(NotFoundDirect("not found"))
+
+	var list List
+	list.Add(BadRequestDirect("bad request"))
+	list.Add(errors.New("plain"))
+	list.Add(&nested)
+
+	if list.Len() != 3 {
+		t.Fatalf("Len() = %v, want 3", list.Len())
+	}
+	if !errors.Is(list.Items()[1], ErrGeneric) {
+		t.Errorf("plain error should be wrapped as ErrGeneric")
+	}
+	if !errors.Is(list.Err(), ErrGeneric) {
+		t.Errorf("errors.Is() through Unwrap() []error = false, want true")
+	}
+}
+
+func TestListCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []int
+		want  int
+	}{
+		{"single", []int{http.StatusBadRequest}, http.StatusBadRequest},
+		{"internal wins", []int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusNotFound}, http.StatusInternalServerError},
+		{"not found over bad request", []int{http.StatusBadRequest, http.StatusNotFound}, http.StatusNotFound},
+		{"listed over unlisted", []int{http.StatusTeapot, http.StatusBadRequest}, http.StatusBadRequest},
+		{"unlisted by value", []int{http.StatusTeapot, http.StatusBadGateway}, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list List
+			for _, code := range tt.codes {
+				list.Add(WrapError(errors.New("cause"), "message", code))
+			}
+			if got := list.Code(); got != tt.want {
+				t.Errorf("Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetStatusPrecedence(t *testing.T) {
+	restoreRegistries(t)
+
+	SetStatusPrecedence(http.StatusBadRequest, http.StatusInternalServerError)
+
+	var list List
+	list.Add(InternalErrorDirect("internal"))
+	list.Add(BadRequestDirect("bad request"))
+
+	if list.Code() != http.StatusBadRequest {
+		t.Errorf("Code() = %v, want %v", list.Code(), http.StatusBadRequest)
+	}
+	if list.Message() != "bad request" {
+		t.Errorf("Message() = %v, want bad request", list.Message())
+	}
+}
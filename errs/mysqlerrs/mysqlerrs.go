@@ -0,0 +1,88 @@
+package mysqlerrs
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/user0608/goones/errs"
+)
+
+// codes maps MySQL error numbers to the equivalent Postgres code
+var codes = map[uint16]errs.PGCode{
+	1062: errs.PgDuplicateRecordError,       // ER_DUP_ENTRY
+	1586: errs.PgDuplicateRecordError,       // ER_DUP_ENTRY_WITH_KEY_NAME
+	1451: errs.PgDependentRecordsError,      // ER_ROW_IS_REFERENCED_2
+	1452: errs.PgDependentRecordsError,      // ER_NO_REFERENCED_ROW_2
+	1406: errs.PgInvalidLengthError,         // ER_DATA_TOO_LONG
+	1048: errs.PgNonNullableFieldsError,     // ER_BAD_NULL_ERROR
+	1364: errs.PgNonNullableFieldsError,     // ER_NO_DEFAULT_FOR_FIELD
+	3819: errs.PgInvalidFormatError,         // ER_CHECK_CONSTRAINT_VIOLATED
+	1264: errs.PgNumericOutOfRangeError,     // ER_WARN_DATA_OUT_OF_RANGE
+	1690: errs.PgNumericOutOfRangeError,     // ER_DATA_OUT_OF_RANGE
+	1365: errs.PgDivisionByZeroError,        // ER_DIVISION_BY_ZERO
+	1366: errs.PgInvalidFieldValueError,     // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+	1292: errs.PgInvalidDatetimeFormatError, // ER_TRUNCATED_WRONG_VALUE
+	3140: errs.PgInvalidJSONValueError,      // ER_INVALID_JSON_TEXT
+	1213: errs.PgDeadlockDetectedError,      // ER_LOCK_DEADLOCK
+	1205: errs.PgLockNotAvailableError,      // ER_LOCK_WAIT_TIMEOUT
+	3024: errs.PgQueryCanceledError,         // ER_QUERY_TIMEOUT
+	1317: errs.PgQueryCanceledError,         // ER_QUERY_INTERRUPTED
+	1146: errs.PgNonexistentResourceError,   // ER_NO_SUCH_TABLE
+	1040: errs.PgTooManyConnectionsError,    // ER_CON_COUNT_ERROR
+	1045: errs.PgUnauthorizedAccessError,    // ER_ACCESS_DENIED_ERROR
+	1044: errs.PgInsufficientPrivilegeError, // ER_DBACCESS_DENIED_ERROR
+	1142: errs.PgInsufficientPrivilegeError, // ER_TABLEACCESS_DENIED_ERROR
+	1290: errs.PgReadOnlyTransactionError,   // ER_OPTION_PREVENTS_STATEMENT
+	1053: errs.PgAdminShutdownError,         // ER_SERVER_SHUTDOWN
+}
+
+var (
+	keyPattern        = regexp.MustCompile("for key '([^']+)'")
+	constraintPattern = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+	checkPattern      = regexp.MustCompile("Check constraint '([^']+)'")
+	tablePattern      = regexp.MustCompile("FOREIGN KEY \\(`[^`]+`\\) REFERENCES `([^`]+)`")
+	columnPattern     = regexp.MustCompile("[Cc]olumn '([^']+)'")
+	fieldPattern      = regexp.MustCompile("Field '([^']+)'")
+)
+
+func init() {
+	errs.RegisterDriverAdapter(Adapter)
+}
+
+// Adapter extracts the diagnostic fields of a *mysql.MySQLError.
+// It is registered in errs when the package is imported.
+func Adapter(err error) (*errs.DBError, bool) {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) {
+		return nil, false
+	}
+
+	dbErr := &errs.DBError{
+		Driver:   "mysql",
+		Code:     strconv.Itoa(int(myErr.Number)),
+		SQLState: string(myErr.SQLState[:]),
+		Message:  myErr.Message,
+	}
+	if code, ok := codes[myErr.Number]; ok {
+		dbErr.SQLState = string(code)
+	}
+
+	dbErr.Constraint = firstMatch(myErr.Message, keyPattern, constraintPattern, checkPattern)
+	dbErr.Column = firstMatch(myErr.Message, columnPattern, fieldPattern)
+	dbErr.MissingReference = myErr.Number == 1452
+	if dbErr.MissingReference {
+		dbErr.Table = firstMatch(myErr.Message, tablePattern)
+	}
+	return dbErr, true
+}
+
+func firstMatch(message string, patterns ...*regexp.Regexp) string {
+	for _, pattern := range patterns {
+		if match := pattern.FindStringSubmatch(message); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package mysqlerrs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/user0608/goones/errs"
+)
+
+func mysqlErr(number uint16, sqlState string, message string) *mysql.MySQLError {
+	myErr := &mysql.MySQLError{Number: number, Message: message}
+	copy(myErr.SQLState[:], sqlState)
+	return myErr
+}
+
+func TestAdapter(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		sqlState   errs.PGCode
+		constraint string
+		column     string
+	}{
+		{"duplicate", mysqlErr(1062, "23000", "Duplicate entry 'a@b.com' for key 'clientes.email'"), errs.PgDuplicateRecordError, "clientes.email", ""},
+		{"row referenced", mysqlErr(1451, "23000", "Cannot delete or update a parent row: a foreign key constraint fails (`db`.`ventas`, CONSTRAINT `ventas_cliente_fk` FOREIGN KEY (`cliente_id`) REFERENCES `clientes` (`id`))"), errs.PgDependentRecordsError, "ventas_cliente_fk", ""},
+		{"too long", mysqlErr(1406, "22001", "Data too long for column 'nombre' at row 1"), errs.PgInvalidLengthError, "", "nombre"},
+		{"not null", mysqlErr(1048, "23000", "Column 'nombre' cannot be null"), errs.PgNonNullableFieldsError, "", "nombre"},
+		{"unmapped number keeps sqlstate", mysqlErr(2013, "08S01", "Lost connection to MySQL server during query"), "08S01", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbErr, ok := Adapter(fmt.Errorf("repository: %w", tt.err))
+			if !ok {
+				t.Fatal("Adapter() = false, want true")
+			}
+			if dbErr.Driver != "mysql" || errs.PGCode(dbErr.SQLState) != tt.sqlState {
+				t.Errorf("Adapter() = driver %q sqlstate %q, want mysql %q", dbErr.Driver, dbErr.SQLState, tt.sqlState)
+			}
+			if dbErr.Constraint != tt.constraint || dbErr.Column != tt.column {
+				t.Errorf("Adapter() = constraint %q column %q, want %q %q", dbErr.Constraint, dbErr.Column, tt.constraint, tt.column)
+			}
+		})
+	}
+}
+
+func TestAdapterIgnoresOtherErrors(t *testing.T) {
+	if _, ok := Adapter(errors.New("boom")); ok {
+		t.Errorf("Adapter() = true for non MySQL error")
+	}
+}
+
+func TestDB(t *testing.T) {
+	duplicate := errs.DB(mysqlErr(1062, "23000", "Duplicate entry 'a@b.com' for key 'clientes.email'")).(*errs.Err)
+	if duplicate.Code() != http.StatusBadRequest || duplicate.SQLState() != string(errs.PgDuplicateRecordError) {
+		t.Errorf("DB() = %v, want duplicate record error", duplicate)
+	}
+
+	missing := errs.DB(mysqlErr(1452, "23000", "Cannot add or update a child row: a foreign key constraint fails (`db`.`ventas`, CONSTRAINT `ventas_cliente_fk` FOREIGN KEY (`cliente_id`) REFERENCES `clientes` (`id`))")).(*errs.Err)
+	deleting := errs.DB(mysqlErr(1451, "23000", "Cannot delete or update a parent row: a foreign key constraint fails")).(*errs.Err)
+	if missing.Message() == deleting.Message() {
+		t.Errorf("DB() should distinguish missing references from dependent records")
+	}
+
+	lost := errs.DB(mysqlErr(2013, "08S01", "Lost connection to MySQL server during query")).(*errs.Err)
+	if lost.Code() != http.StatusServiceUnavailable {
+		t.Errorf("Code() = %v, want class fallback %v", lost.Code(), http.StatusServiceUnavailable)
+	}
+}
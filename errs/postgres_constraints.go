@@ -0,0 +1,35 @@
+package errs
+
+// PgConstraint describes the error returned when a specific Postgres constraint or column fails.
+// Empty fields fall back to the sentinel named by AppCode and then to the SQLSTATE entry.
+type PgConstraint struct {
+	Message  string
+	Field    string
+	AppCode  string
+	HTTPCode int
+}
+
+// AddPgConstraint registers the error for a constraint name, such as "clientes_email_key",
+// or for a column written as "table.column", used when Postgres reports no constraint (e.g. 23502).
+func AddPgConstraint(name string, constraint PgConstraint) {
+	defaultTranslator.AddPgConstraint(name, constraint)
+}
+
+func constraintError(cause error, constraint PgConstraint, state details) *Err {
+	result := &Err{message: state.message, httpCode: state.httpCode}
+	if sentinel, ok := Lookup(constraint.AppCode); ok {
+		result = sentinel.Wrap(nil).(*Err)
+	}
+	result.wrapped = cause
+	result.field = constraint.Field
+	if constraint.AppCode != "" {
+		result.appCode = constraint.AppCode
+	}
+	if constraint.Message != "" {
+		result.message = constraint.Message
+	}
+	if constraint.HTTPCode != 0 {
+		result.httpCode = constraint.HTTPCode
+	}
+	return result
+}
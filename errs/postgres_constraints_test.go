@@ -0,0 +1,87 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestPgfConstraintMessages(t *testing.T) {
+	t.Cleanup(DefaultPgTranslator().Reset)
+
+	AddPgConstraint("test_clientes_email_key", PgConstraint{
+		Message: "El correo ya está registrado.",
+		Field:   "email",
+		AppCode: "CLIENTE_EMAIL_DUPLICADO",
+	})
+	AddPgConstraint("test_clientes_doc_key", PgConstraint{
+		Message:  "El documento ya está registrado.",
+		Field:    "doc",
+		HTTPCode: http.StatusConflict,
+	})
+
+	email := Pgf(&pgconn.PgError{Code: string(PgDuplicateRecordError), ConstraintName: "test_clientes_email_key"}).(*Err)
+	doc := Pgf(&pgconn.PgError{Code: string(PgDuplicateRecordError), ConstraintName: "test_clientes_doc_key"}).(*Err)
+
+	if email.Message() == doc.Message() {
+		t.Fatalf("expected different messages, got %q", email.Message())
+	}
+	if email.Field() != "email" || email.AppCode() != "CLIENTE_EMAIL_DUPLICADO" {
+		t.Errorf("email error = field %q code %q", email.Field(), email.AppCode())
+	}
+	if email.Code() != http.StatusBadRequest {
+		t.Errorf("Code() = %v, want SQLSTATE fallback %v", email.Code(), http.StatusBadRequest)
+	}
+	if doc.Code() != http.StatusConflict {
+		t.Errorf("Code() = %v, want %v", doc.Code(), http.StatusConflict)
+	}
+	if email.SQLState() != string(PgDuplicateRecordError) {
+		t.Errorf("SQLState() = %v, want %v", email.SQLState(), PgDuplicateRecordError)
+	}
+}
+
+func TestPgfConstraintByColumn(t *testing.T) {
+	t.Cleanup(DefaultPgTranslator().Reset)
+
+	AddPgConstraint("test_clientes.nombre", PgConstraint{Field: "nombre"})
+
+	got := Pgf(&pgconn.PgError{
+		Code:       string(PgNonNullableFieldsError),
+		TableName:  "test_clientes",
+		ColumnName: "nombre",
+	}).(*Err)
+
+	if got.Field() != "nombre" {
+		t.Errorf("Field() = %v, want nombre", got.Field())
+	}
+	if got.Message() != defaultPgErrcodes[PgNonNullableFieldsError].message {
+		t.Errorf("Message() = %v, want SQLSTATE message", got.Message())
+	}
+}
+
+func TestPgfConstraintSentinel(t *testing.T) {
+	t.Cleanup(DefaultPgTranslator().Reset)
+	restoreRegistries(t)
+
+	sentinel := Define("TEST_CONSTRAINT_SENTINEL", http.StatusConflict, "La categoría ya existe.")
+	AddPgConstraint("test_categorias_nombre_key", PgConstraint{AppCode: "TEST_CONSTRAINT_SENTINEL", Field: "nombre"})
+
+	got := Pgf(&pgconn.PgError{Code: string(PgDuplicateRecordError), ConstraintName: "test_categorias_nombre_key"})
+
+	if !errors.Is(got, sentinel) {
+		t.Errorf("errors.Is() = false, want true")
+	}
+	if got.(*Err).Code() != http.StatusConflict || got.(*Err).Message() != "La categoría ya existe." {
+		t.Errorf("Pgf() = %v, want sentinel code and message", got)
+	}
+}
+
+func TestPgfUnregisteredConstraintFallsBack(t *testing.T) {
+	got := Pgf(&pgconn.PgError{Code: string(PgDuplicateRecordError), ConstraintName: "unknown_key"}).(*Err)
+
+	if got.Message() != defaultPgErrcodes[PgDuplicateRecordError].message || got.Field() != "" {
+		t.Errorf("Pgf() = %q field %q, want SQLSTATE entry", got.Message(), got.Field())
+	}
+}
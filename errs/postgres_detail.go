@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"strings"
+)
+
+// pgDetailSuffixes are the endings of the Key (...)=(...) details Postgres
+// reports for unique, foreign key and exclusion violations
+var pgDetailSuffixes = []string{
+	") already exists",
+	") is not present in table ",
+	") is still referenced from table ",
+	") conflicts with existing key ",
+}
+
+// parsePgDetail reads details such as
+//
+//	Key (email)=(a@b.com) already exists.
+//	Key (cliente_id)=(42) is not present in table "clientes".
+//
+// Composite keys keep the comma separated form reported by Postgres.
+func parsePgDetail(detail string) (column string, value string, table string, ok bool) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(detail), "Key (")
+	if !found {
+		return "", "", "", false
+	}
+
+	column, rest, found = strings.Cut(rest, ")=(")
+	if !found {
+		return "", "", "", false
+	}
+
+	for _, suffix := range pgDetailSuffixes {
+		index := strings.Index(rest, suffix)
+		if index < 0 {
+			continue
+		}
+		value = rest[:index]
+		tail := rest[index+len(suffix):]
+		if strings.HasPrefix(tail, `"`) {
+			table, _, _ = strings.Cut(tail[1:], `"`)
+		}
+		return column, value, table, true
+	}
+	return "", "", "", false
+}
@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestParsePgDetail(t *testing.T) {
+	tests := []struct {
+		detail string
+		column string
+		value  string
+		table  string
+		ok     bool
+	}{
+		{"Key (email)=(a@b.com) already exists.", "email", "a@b.com", "", true},
+		{`Key (cliente_id)=(42) is not present in table "clientes".`, "cliente_id", "42", "clientes", true},
+		{`Key (id)=(7) is still referenced from table "ventas".`, "id", "7", "ventas", true},
+		{"Key (empresa_id, doc)=(1, 4567) already exists.", "empresa_id, doc", "1, 4567", "", true},
+		{"Key (lower(email::text))=(a@b.com) already exists.", "lower(email::text)", "a@b.com", "", true},
+		{"Failing row contains (1, null).", "", "", "", false},
+		{"", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.detail, func(t *testing.T) {
+			column, value, table, ok := parsePgDetail(tt.detail)
+			if column != tt.column || value != tt.value || table != tt.table || ok != tt.ok {
+				t.Errorf("parsePgDetail() = %q %q %q %v, want %q %q %q %v", column, value, table, ok, tt.column, tt.value, tt.table, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPgfDetailFields(t *testing.T) {
+	got := Pgf(&pgconn.PgError{
+		Code:    string(PgDependentRecordsError),
+		Message: "insert or update on table \"ventas\" violates foreign key constraint \"ventas_cliente_id_fkey\"",
+		Detail:  `Key (cliente_id)=(42) is not present in table "clientes".`,
+	}).(*Err)
+
+	if got.Column() != "cliente_id" || got.Value() != "42" || got.ReferencedTable() != "clientes" {
+		t.Errorf("Pgf() = column %q value %q table %q", got.Column(), got.Value(), got.ReferencedTable())
+	}
+	if got.ExposedValue() != "" || got.Field() != "" {
+		t.Errorf("values must not be exposed by default, got field %q value %q", got.Field(), got.ExposedValue())
+	}
+}
+
+func TestPgTranslatorExposeValues(t *testing.T) {
+	translator := NewPgTranslator()
+	translator.ExposeValues(true)
+	translator.AddPgConstraint("clientes.email", PgConstraint{Field: "correo"})
+
+	duplicate := &pgconn.PgError{
+		Code:      string(PgDuplicateRecordError),
+		TableName: "clientes",
+		Detail:    "Key (email)=(a@b.com) already exists.",
+	}
+	got := translator.Pgf(duplicate).(*Err)
+	if got.Field() != "correo" || got.ExposedValue() != "a@b.com" {
+		t.Errorf("Pgf() = field %q value %q, want correo a@b.com", got.Field(), got.ExposedValue())
+	}
+
+	duplicate.TableName = "proveedores"
+	got = translator.Pgf(duplicate).(*Err)
+	if got.Field() != "email" {
+		t.Errorf("Field() = %q, want column email as fallback", got.Field())
+	}
+}
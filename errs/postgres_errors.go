@@ -1,13 +1,8 @@
 package errs
 
 import (
-	"errors"
 	"net/http"
 	"strings"
-	"sync"
-
-	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/user0608/ifdevmode"
 )
 
 type details struct {
@@ -32,100 +27,216 @@ const (
 	PgInvalidFieldValueError   PGCode = "22P02"
 	PgInvalidJSONValueError    PGCode = "22032"
 	PgNonNullableFieldsError   PGCode = "23502"
+
+	PgNumericOutOfRangeError      PGCode = "22003"
+	PgInvalidDatetimeFormatError  PGCode = "22007"
+	PgDatetimeOverflowError       PGCode = "22008"
+	PgDivisionByZeroError         PGCode = "22012"
+	PgRestrictViolationError      PGCode = "23001"
+	PgExclusionViolationError     PGCode = "23P01"
+	PgReadOnlyTransactionError    PGCode = "25006"
+	PgIdleInTransactionError      PGCode = "25P03"
+	PgSerializationFailureError   PGCode = "40001"
+	PgDeadlockDetectedError       PGCode = "40P01"
+	PgInsufficientPrivilegeError  PGCode = "42501"
+	PgDiskFullError               PGCode = "53100"
+	PgOutOfMemoryError            PGCode = "53200"
+	PgTooManyConnectionsError     PGCode = "53300"
+	PgLockNotAvailableError       PGCode = "55P03"
+	PgQueryCanceledError          PGCode = "57014"
+	PgAdminShutdownError          PGCode = "57P01"
+	PgCrashShutdownError          PGCode = "57P02"
+	PgCannotConnectNowError       PGCode = "57P03"
+	PgIdleSessionTimeoutError     PGCode = "57P05"
+	PgRaiseExceptionError         PGCode = "P0001"
+	PgConnectionFailureError      PGCode = "08006"
+	PgConnectionDoesNotExistError PGCode = "08003"
 )
 
-var (
-	mutex      sync.RWMutex
-	devmode    = ifdevmode.Yes()
-	pgErrcodes = map[PGCode]details{
-		PgInvalidLengthError:       {"Verifique que los campos tengan la longitud correcta de caracteres.", http.StatusBadRequest, false},
-		PgDuplicateRecordError:     {"El registro ya existe en la base de datos del sistema.", http.StatusBadRequest, false},
-		PgInvalidFormatError:       {"Uno de los campos no tiene el formato correcto. Consulte con el administrador del sistema.", http.StatusBadRequest, false},
-		PgDependentRecordsError:    {"Se encontraron otros registros dependientes. No podemos realizar ninguna acción mientras estas relaciones existan.", http.StatusBadRequest, false},
-		PgDataIntegrityError:       {"Operación restringida debido a un problema de integridad en los datos. Consulte la documentación.", http.StatusBadRequest, false},
-		PgOperationFailedError:     {"No se pudieron completar las operaciones. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
-		PgInternalProblemError:     {"Hubo un problema interno. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
-		PgUnauthorizedAccessError:  {"Acceso restringido. No podemos realizar la operación.", http.StatusUnauthorized, true},
-		PgTransactionError:         {"Hubo un problema al realizar la transacción. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
-		PgNonexistentResourceError: {"El registro o recurso al que intenta acceder no existe.", http.StatusBadRequest, false},
-		PgInvalidFieldValueError:   {"El formato o representación de uno de los valores de campo no cumple con los requerimientos.", http.StatusBadRequest, false},
-		PgInvalidJSONValueError:    {"El valor asignado a uno de los campos de tipo JSON no cumple con los requerimientos.", http.StatusBadRequest, false},
-		PgNonNullableFieldsError:   {"Hay campos que no deberían ser nulos. Consulte la documentación o al administrador del sistema.", http.StatusBadRequest, false},
-	}
+// SQLSTATE classes, used as fallback for the codes without their own entry
+const (
+	PgClassSQLStatementNotYetComplete    PGCode = "03"
+	PgClassConnectionException           PGCode = "08"
+	PgClassTriggeredActionException      PGCode = "09"
+	PgClassFeatureNotSupported           PGCode = "0A"
+	PgClassInvalidTransactionInitiation  PGCode = "0B"
+	PgClassLocatorException              PGCode = "0F"
+	PgClassInvalidGrantor                PGCode = "0L"
+	PgClassInvalidRoleSpecification      PGCode = "0P"
+	PgClassDiagnosticsException          PGCode = "0Z"
+	PgClassCaseNotFound                  PGCode = "20"
+	PgClassCardinalityViolation          PGCode = "21"
+	PgClassDataException                 PGCode = "22"
+	PgClassIntegrityConstraintViolation  PGCode = "23"
+	PgClassInvalidCursorState            PGCode = "24"
+	PgClassInvalidTransactionState       PGCode = "25"
+	PgClassInvalidSQLStatementName       PGCode = "26"
+	PgClassTriggeredDataChangeViolation  PGCode = "27"
+	PgClassInvalidAuthorization          PGCode = "28"
+	PgClassDependentPrivilegeDescriptors PGCode = "2B"
+	PgClassInvalidTransactionTermination PGCode = "2D"
+	PgClassSQLRoutineException           PGCode = "2F"
+	PgClassInvalidCursorName             PGCode = "34"
+	PgClassExternalRoutineException      PGCode = "38"
+	PgClassExternalRoutineInvocation     PGCode = "39"
+	PgClassSavepointException            PGCode = "3B"
+	PgClassInvalidCatalogName            PGCode = "3D"
+	PgClassInvalidSchemaName             PGCode = "3F"
+	PgClassTransactionRollback           PGCode = "40"
+	PgClassSyntaxErrorOrAccessRule       PGCode = "42"
+	PgClassWithCheckOptionViolation      PGCode = "44"
+	PgClassInsufficientResources         PGCode = "53"
+	PgClassProgramLimitExceeded          PGCode = "54"
+	PgClassObjectNotInPrerequisiteState  PGCode = "55"
+	PgClassOperatorIntervention          PGCode = "57"
+	PgClassSystemError                   PGCode = "58"
+	PgClassSnapshotFailure               PGCode = "72"
+	PgClassConfigFileError               PGCode = "F0"
+	PgClassForeignDataWrapperError       PGCode = "HV"
+	PgClassPLpgSQLError                  PGCode = "P0"
+	PgClassInternalError                 PGCode = "XX"
+)
+
+const (
+	messageInternal    = "Hubo un problema interno. Por favor, informe la incidencia al equipo técnico."
+	messageUnavailable = "El servicio de base de datos no está disponible en este momento. Por favor, vuelva a intentar en unos minutos."
+	messageConcurrency = "La operación entró en conflicto con otra operación en curso. Por favor, vuelva a intentar."
+	messageInvalidData = "Uno de los valores enviados no es válido para la operación solicitada."
 )
 
+// defaultPgErrcodes is the table every PgTranslator starts from
+var defaultPgErrcodes = map[PGCode]details{
+	PgInvalidLengthError:       {"Verifique que los campos tengan la longitud correcta de caracteres.", http.StatusBadRequest, false},
+	PgDuplicateRecordError:     {"El registro ya existe en la base de datos del sistema.", http.StatusBadRequest, false},
+	PgInvalidFormatError:       {"Uno de los campos no tiene el formato correcto. Consulte con el administrador del sistema.", http.StatusBadRequest, false},
+	PgDependentRecordsError:    {"Se encontraron otros registros dependientes. No podemos realizar ninguna acción mientras estas relaciones existan.", http.StatusBadRequest, false},
+	PgDataIntegrityError:       {"Operación restringida debido a un problema de integridad en los datos. Consulte la documentación.", http.StatusBadRequest, false},
+	PgOperationFailedError:     {"No se pudieron completar las operaciones. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
+	PgInternalProblemError:     {messageInternal, http.StatusInternalServerError, true},
+	PgUnauthorizedAccessError:  {"Acceso restringido. No podemos realizar la operación.", http.StatusUnauthorized, true},
+	PgTransactionError:         {"Hubo un problema al realizar la transacción. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
+	PgNonexistentResourceError: {"El registro o recurso al que intenta acceder no existe.", http.StatusBadRequest, false},
+	PgInvalidFieldValueError:   {"El formato o representación de uno de los valores de campo no cumple con los requerimientos.", http.StatusBadRequest, false},
+	PgInvalidJSONValueError:    {"El valor asignado a uno de los campos de tipo JSON no cumple con los requerimientos.", http.StatusBadRequest, false},
+	PgNonNullableFieldsError:   {"Hay campos que no deberían ser nulos. Consulte la documentación o al administrador del sistema.", http.StatusBadRequest, false},
+
+	PgNumericOutOfRangeError:      {"Uno de los valores numéricos está fuera del rango permitido.", http.StatusBadRequest, false},
+	PgInvalidDatetimeFormatError:  {"Una de las fechas u horas no tiene el formato correcto.", http.StatusBadRequest, false},
+	PgDatetimeOverflowError:       {"Una de las fechas u horas está fuera del rango permitido.", http.StatusBadRequest, false},
+	PgDivisionByZeroError:         {"La operación no se pudo realizar porque implica una división entre cero.", http.StatusBadRequest, false},
+	PgRestrictViolationError:      {"Se encontraron otros registros dependientes. No podemos realizar ninguna acción mientras estas relaciones existan.", http.StatusBadRequest, false},
+	PgExclusionViolationError:     {"El registro entra en conflicto con otro registro existente.", http.StatusBadRequest, false},
+	PgReadOnlyTransactionError:    {"La base de datos solo permite lectura en este momento. Por favor, vuelva a intentar más tarde.", http.StatusServiceUnavailable, true},
+	PgIdleInTransactionError:      {"La transacción expiró por inactividad. Por favor, vuelva a intentar.", http.StatusServiceUnavailable, true},
+	PgSerializationFailureError:   {messageConcurrency, http.StatusConflict, true},
+	PgDeadlockDetectedError:       {messageConcurrency, http.StatusConflict, true},
+	PgInsufficientPrivilegeError:  {"No tiene permisos suficientes para realizar la operación.", http.StatusForbidden, true},
+	PgDiskFullError:               {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgOutOfMemoryError:            {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgTooManyConnectionsError:     {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgLockNotAvailableError:       {"El registro está siendo modificado por otra operación. Por favor, vuelva a intentar.", http.StatusConflict, true},
+	PgQueryCanceledError:          {messageTimeout, http.StatusGatewayTimeout, true},
+	PgAdminShutdownError:          {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgCrashShutdownError:          {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgCannotConnectNowError:       {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgIdleSessionTimeoutError:     {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgRaiseExceptionError:         {"La operación fue rechazada por una regla de negocio del sistema.", http.StatusBadRequest, true},
+	PgConnectionFailureError:      {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgConnectionDoesNotExistError: {messageUnavailable, http.StatusServiceUnavailable, true},
+
+	PgClassSQLStatementNotYetComplete:    {messageInternal, http.StatusInternalServerError, true},
+	PgClassConnectionException:           {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgClassTriggeredActionException:      {messageInternal, http.StatusInternalServerError, true},
+	PgClassFeatureNotSupported:           {"La operación solicitada no está soportada por la base de datos.", http.StatusNotImplemented, true},
+	PgClassInvalidTransactionInitiation:  {messageInternal, http.StatusInternalServerError, true},
+	PgClassLocatorException:              {messageInternal, http.StatusInternalServerError, true},
+	PgClassInvalidGrantor:                {"No tiene permisos suficientes para realizar la operación.", http.StatusForbidden, true},
+	PgClassInvalidRoleSpecification:      {"No tiene permisos suficientes para realizar la operación.", http.StatusForbidden, true},
+	PgClassDiagnosticsException:          {messageInternal, http.StatusInternalServerError, true},
+	PgClassCaseNotFound:                  {messageInternal, http.StatusInternalServerError, true},
+	PgClassCardinalityViolation:          {messageInternal, http.StatusInternalServerError, true},
+	PgClassDataException:                 {messageInvalidData, http.StatusBadRequest, false},
+	PgClassIntegrityConstraintViolation:  {"Operación restringida debido a un problema de integridad en los datos. Consulte la documentación.", http.StatusBadRequest, false},
+	PgClassInvalidCursorState:            {messageInternal, http.StatusInternalServerError, true},
+	PgClassInvalidTransactionState:       {"No se pudieron completar las operaciones. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
+	PgClassInvalidSQLStatementName:       {messageInternal, http.StatusInternalServerError, true},
+	PgClassTriggeredDataChangeViolation:  {messageInternal, http.StatusInternalServerError, true},
+	PgClassInvalidAuthorization:          {"Acceso restringido. No podemos realizar la operación.", http.StatusUnauthorized, true},
+	PgClassDependentPrivilegeDescriptors: {"No tiene permisos suficientes para realizar la operación.", http.StatusForbidden, true},
+	PgClassInvalidTransactionTermination: {"Hubo un problema al realizar la transacción. Por favor, informe la incidencia al equipo técnico.", http.StatusInternalServerError, true},
+	PgClassSQLRoutineException:           {messageInternal, http.StatusInternalServerError, true},
+	PgClassInvalidCursorName:             {messageInternal, http.StatusInternalServerError, true},
+	PgClassExternalRoutineException:      {messageInternal, http.StatusInternalServerError, true},
+	PgClassExternalRoutineInvocation:     {messageInternal, http.StatusInternalServerError, true},
+	PgClassSavepointException:            {messageInternal, http.StatusInternalServerError, true},
+	PgClassInvalidCatalogName:            {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgClassInvalidSchemaName:             {messageInternal, http.StatusInternalServerError, true},
+	PgClassTransactionRollback:           {messageConcurrency, http.StatusConflict, true},
+	PgClassSyntaxErrorOrAccessRule:       {messageInternal, http.StatusInternalServerError, true},
+	PgClassWithCheckOptionViolation:      {"No tiene permisos suficientes para modificar el registro.", http.StatusForbidden, false},
+	PgClassInsufficientResources:         {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgClassProgramLimitExceeded:          {"La operación excede los límites permitidos por la base de datos.", http.StatusBadRequest, true},
+	PgClassObjectNotInPrerequisiteState:  {"El recurso no se encuentra en un estado válido para la operación. Por favor, vuelva a intentar.", http.StatusConflict, true},
+	PgClassOperatorIntervention:          {messageUnavailable, http.StatusServiceUnavailable, true},
+	PgClassSystemError:                   {messageInternal, http.StatusInternalServerError, true},
+	PgClassSnapshotFailure:               {messageInternal, http.StatusInternalServerError, true},
+	PgClassConfigFileError:               {messageInternal, http.StatusInternalServerError, true},
+	PgClassForeignDataWrapperError:       {messageInternal, http.StatusInternalServerError, true},
+	PgClassPLpgSQLError:                  {messageInternal, http.StatusInternalServerError, true},
+	PgClassInternalError:                 {messageInternal, http.StatusInternalServerError, true},
+}
+
+// Devmode keeps the original driver errors and exposes debug information in responses
 func Devmode() {
-	devmode = true
+	defaultTranslator.Devmode()
 }
 
-func AddPgErrs(code PGCode, message string, httpCode int, loggable bool) {
-	mutex.Lock()
-	defer mutex.Unlock()
-	pgErrcodes[code] = details{message, httpCode, loggable}
+func IsDevmode() bool {
+	return defaultTranslator.IsDevmode()
 }
 
-const (
-	ErrInvalidRequestBody          = "La estructura de información enviada es inválida. Por favor, revise la documentación y vuelva a intentar."
-	ErrInvalidQueryParam           = "Los parámetros de consulta son inválidos. Favor de revisar la documentación y volver a intentar."
-	ErrAuthorizationHeaderNotFound = "La cabecera con el token de utilización no fue encontrada. La operación fue rechazada."
-	ErrInvalidToken                = "El token que está utilizando no es válido o ha caducado. Contáctese con el equipo técnico."
-	ErrSigningTokenString          = "El token que está utilizando no es genuino. Contáctese con el equipo técnico."
-	ErrDatabase                    = "La operación no se pudo realizar debido a algún problema. Contáctese con el equipo técnico."
-
-	ErrRecordNotFound        = "El registro buscado no fue encontrado."
-	ErrCreating              = "No se pudo realizar la operación de registro."
-	ErrUpdating              = "No se pudo realizar la operación de actualización."
-	ErrUserOrPasswordInvalid = "Usuario o contraseña incorrectos."
-	ErrIDNotFound            = "Parámetro ID o identificador no encontrado."
-	ErrCodeNotFound          = "Parámetro código no encontrado."
-	ErrNameNotFound          = "Parámetro nombre no encontrado."
-	ErrNotFound              = "No se pudo encontrar ningún recurso asociado a esta consulta."
-	ErrGeneric               = "Hubo un error inesperado. Favor de reportar la incidencia al equipo técnico."
-	ErrInternal              = ErrGeneric
-)
+// AddPgErrs sets the error for a SQLSTATE code, or for a whole class when code has two characters
+func AddPgErrs(code PGCode, message string, httpCode int, loggable bool) {
+	defaultTranslator.AddPgErrs(code, message, httpCode, loggable)
+}
 
 const message23503 = "No se puede realizar la operación debido a asociaciones incompatibles. Asegúrese de que los valores relacionados existan antes de intentar el registro."
 
+// Pgf translates a database error into an *Err. It is kept for compatibility, see DB.
 func Pgf(err error) error {
-	if err == nil {
-		return nil
-	}
-
-	if strings.Contains(err.Error(), "record not found") {
-		return newError(err, ErrRecordNotFound, http.StatusBadRequest)
-	}
-
-	var pgerr *pgconn.PgError
-	if !errors.As(err, &pgerr) {
-		return newError(err, ErrDatabase, http.StatusInternalServerError)
-	}
-
-	code := PGCode(pgerr.Code)
-
-	if code == PgDependentRecordsError &&
-		strings.Contains(pgerr.Message, "insert or update") {
-		return newError(err, message23503, http.StatusBadRequest)
-	}
+	return DB(err)
+}
 
-	mutex.RLock()
-	state, ok := pgErrcodes[code]
-	mutex.RUnlock()
+// ExposeValues allows the offending values parsed from the database error to reach clients
+func ExposeValues(expose bool) {
+	defaultTranslator.ExposeValues(expose)
+}
 
-	if !ok {
-		return newError(err, ErrDatabase, http.StatusInternalServerError)
-	}
+// DB translates an error returned by any database driver with a registered
+// adapter into an *Err, using the Postgres table for every driver.
+func DB(err error) error {
+	return defaultTranslator.DB(err)
+}
 
-	if state.loggable || devmode {
-		return newError(err, state.message, state.httpCode)
+// withPgError copies the Postgres diagnostic fields into err as internal detail
+func withPgError(err error, pgerr *DBError) *Err {
+	customErr := err.(*Err)
+	customErr.sqlState = pgerr.SQLState
+	customErr.constraint = pgerr.Constraint
+	customErr.table = pgerr.Table
+	customErr.detail = strings.TrimSpace(pgerr.Message + " " + pgerr.Detail)
+	customErr.column = pgerr.Column
+	if column, value, table, ok := parsePgDetail(pgerr.Detail); ok {
+		customErr.column = column
+		customErr.value = value
+		customErr.refTable = table
 	}
-
-	return newError(nil, state.message, state.httpCode)
+	return customErr
 }
 
 func IsPgErrCode(err error, code PGCode) bool {
-	var pgerr *pgconn.PgError
-	if errors.As(err, &pgerr) {
-		return PGCode(pgerr.Code) == code
+	if pgerr, ok := ExtractDBError(err); ok {
+		return PGCode(pgerr.SQLState) == code
 	}
 	return false
 }
@@ -2,7 +2,9 @@ package errs
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -15,15 +17,34 @@ func TestPgfNil(t *testing.T) {
 }
 
 func TestPgfRecordNotFound(t *testing.T) {
-	err := Pgf(errors.New("record not found"))
+	restoreRegistries(t)
+
+	gormNotFound := errors.New("record not found")
+	RegisterNoRows(gormNotFound)
+
+	err := Pgf(fmt.Errorf("find: %w", gormNotFound))
 
 	if err == nil {
 		t.Fatal("expected error")
 	}
 
-	// Ajusta estos asserts según la API real de tu custom error.
-	if err.Error() == "" {
-		t.Fatal("expected non-empty error message")
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got %v", err)
+	}
+
+	if code := err.(*Err).Code(); code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, code)
+	}
+}
+
+func TestPgfRaiseExceptionIsNotRecordNotFound(t *testing.T) {
+	err := Pgf(&pgconn.PgError{Code: "P0001", Message: "record not found for tenant"})
+
+	if errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("RAISE EXCEPTION reported as ErrRecordNotFound: %v", err)
+	}
+	if got := err.(*Err).SQLState(); got != "P0001" {
+		t.Errorf("SQLState() = %v, want P0001", got)
 	}
 }
 
@@ -145,3 +166,84 @@ func TestIsPgErrCodeFalseForNonPgError(t *testing.T) {
 		t.Fatal("expected false")
 	}
 }
+
+func TestPgfKeepsPgDiagnostics(t *testing.T) {
+	err := &pgconn.PgError{
+		Code:           string(PgDuplicateRecordError),
+		Message:        "duplicate key value violates unique constraint",
+		ConstraintName: "clientes_email_key",
+		TableName:      "clientes",
+	}
+
+	var customErr *Err
+	if !errors.As(Pgf(err), &customErr) {
+		t.Fatal("expected *Err")
+	}
+
+	if customErr.SQLState() != string(PgDuplicateRecordError) {
+		t.Errorf("SQLState() = %v, want %v", customErr.SQLState(), PgDuplicateRecordError)
+	}
+	if customErr.Constraint() != "clientes_email_key" {
+		t.Errorf("Constraint() = %v, want clientes_email_key", customErr.Constraint())
+	}
+	if customErr.Table() != "clientes" {
+		t.Errorf("Table() = %v, want clientes", customErr.Table())
+	}
+}
+
+func TestPgfKeepsInternalTextOutOfMessage(t *testing.T) {
+	err := &pgconn.PgError{
+		Code:           string(PgDuplicateRecordError),
+		Message:        "duplicate key value violates unique constraint \"clientes_email_key\"",
+		Detail:         "Key (email)=(a@b.com) already exists.",
+		ConstraintName: "clientes_email_key",
+	}
+
+	customErr := Pgf(err).(*Err)
+
+	if strings.Contains(customErr.Message(), "clientes_email_key") {
+		t.Errorf("Message() leaks constraint name: %v", customErr.Message())
+	}
+	if !strings.Contains(customErr.Detail(), "clientes_email_key") || !strings.Contains(customErr.Detail(), "a@b.com") {
+		t.Errorf("Detail() = %v, want Postgres message and detail", customErr.Detail())
+	}
+}
+
+func TestPgfSQLStateCoverage(t *testing.T) {
+	tests := []struct {
+		code PGCode
+		want int
+	}{
+		{PgDeadlockDetectedError, http.StatusConflict},
+		{PgSerializationFailureError, http.StatusConflict},
+		{PgQueryCanceledError, http.StatusGatewayTimeout},
+		{PgDiskFullError, http.StatusServiceUnavailable},
+		{PgTooManyConnectionsError, http.StatusServiceUnavailable},
+		{PgExclusionViolationError, http.StatusBadRequest},
+		{PgInsufficientPrivilegeError, http.StatusForbidden},
+		{"08001", http.StatusServiceUnavailable},
+		{"22019", http.StatusBadRequest},
+		{"XX001", http.StatusInternalServerError},
+		{"99999", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			got := Pgf(&pgconn.PgError{Code: string(tt.code)}).(*Err)
+			if got.Code() != tt.want {
+				t.Errorf("Code() = %v, want %v", got.Code(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAddPgErrsClass(t *testing.T) {
+	t.Cleanup(DefaultPgTranslator().Reset)
+
+	AddPgErrs("HV", "custom fdw error", http.StatusBadGateway, true)
+
+	got := Pgf(&pgconn.PgError{Code: "HV00N"}).(*Err)
+	if got.Code() != http.StatusBadGateway || got.Message() != "custom fdw error" {
+		t.Errorf("Pgf() = %d %q, want class override", got.Code(), got.Message())
+	}
+}
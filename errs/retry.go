@@ -0,0 +1,146 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryPolicy configures the exponential backoff used by Retry
+type RetryPolicy struct {
+	// MaxAttempts is the total number of calls, including the first one
+	MaxAttempts int
+	// InitialDelay is the wait before the second attempt
+	InitialDelay time.Duration
+	// MaxDelay caps the wait between attempts
+	MaxDelay time.Duration
+	// Multiplier grows the wait after every attempt
+	Multiplier float64
+	// Jitter randomizes every wait by ±Jitter (0 to 1) of its value
+	Jitter float64
+	// ShouldRetry decides whether an error is retried, IsRetryable when nil
+	ShouldRetry func(error) bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:  3,
+	InitialDelay: 50 * time.Millisecond,
+	MaxDelay:     2 * time.Second,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
+// IsTransient reports whether err comes from a temporary condition of the
+// database or the network: lost connections, server shutdowns, too many
+// connections or deadlines.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	code := sqlState(err)
+	switch code {
+	case PgAdminShutdownError, PgCrashShutdownError, PgCannotConnectNowError,
+		PgTooManyConnectionsError, PgIdleSessionTimeoutError:
+		return true
+	}
+	return len(code) == 5 && code[:2] == PgClassConnectionException
+}
+
+// IsRetryable reports whether the operation that failed with err can be run
+// again: transient errors plus serialization failures, deadlocks and lock timeouts.
+func IsRetryable(err error) bool {
+	switch sqlState(err) {
+	case PgSerializationFailureError, PgDeadlockDetectedError, PgLockNotAvailableError:
+		return true
+	}
+	return IsTransient(err)
+}
+
+// sqlState returns the Postgres code carried by err, either from the driver error or from an *Err
+func sqlState(err error) PGCode {
+	if dbErr, ok := ExtractDBError(err); ok {
+		return PGCode(dbErr.SQLState)
+	}
+	var customErr *Err
+	if errors.As(err, &customErr) {
+		return PGCode(customErr.sqlState)
+	}
+	return ""
+}
+
+// Retry calls fn until it succeeds, returns an error that policy does not
+// retry, runs out of attempts or ctx is done. It returns the last error of fn.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	shouldRetry := policy.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = IsRetryable
+	}
+
+	attempts := max(policy.MaxAttempts, 1)
+	delay := policy.InitialDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return ctxErr
+			}
+			return err
+		}
+
+		err = fn(ctx)
+		if err == nil || attempt >= attempts || !shouldRetry(err) {
+			return err
+		}
+
+		timer := time.NewTimer(withJitter(delay, policy.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		delay = nextDelay(delay, policy)
+	}
+}
+
+func nextDelay(delay time.Duration, policy RetryPolicy) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	next := time.Duration(float64(delay) * multiplier)
+	if policy.MaxDelay > 0 && next > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return next
+}
+
+func withJitter(delay time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || delay <= 0 {
+		return delay
+	}
+	jitter = min(jitter, 1)
+	factor := 1 - jitter + rand.Float64()*2*jitter
+	return time.Duration(float64(delay) * factor)
+}
@@ -0,0 +1,126 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsRetryableAndTransient(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		transient bool
+	}{
+		{"nil", nil, false, false},
+		{"serialization failure", &pgconn.PgError{Code: string(PgSerializationFailureError)}, true, false},
+		{"deadlock through Pgf", Pgf(&pgconn.PgError{Code: string(PgDeadlockDetectedError)}), true, false},
+		{"admin shutdown", &pgconn.PgError{Code: string(PgAdminShutdownError)}, true, true},
+		{"connection exception class", &pgconn.PgError{Code: "08001"}, true, true},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true, true},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), true, true},
+		{"canceled", context.Canceled, false, false},
+		{"duplicate", &pgconn.PgError{Code: string(PgDuplicateRecordError)}, false, false},
+		{"plain", errors.New("boom"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.retryable)
+			}
+			if got := IsTransient(tt.err); got != tt.transient {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.transient)
+			}
+		})
+	}
+}
+
+var fastPolicy = RetryPolicy{MaxAttempts: 4, InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, Multiplier: 2, Jitter: 0.5}
+
+func TestRetrySucceedsAfterRetryableErrors(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), fastPolicy, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return &pgconn.PgError{Code: string(PgSerializationFailureError)}
+		}
+		return nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Errorf("Retry() = %v after %d calls, want nil after 3", err, calls)
+	}
+}
+
+func TestRetryStopsOnPermanentError(t *testing.T) {
+	calls := 0
+	permanent := &pgconn.PgError{Code: string(PgDuplicateRecordError)}
+	err := Retry(context.Background(), fastPolicy, func(ctx context.Context) error {
+		calls++
+		return permanent
+	})
+
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("Retry() = %v after %d calls, want permanent error after 1", err, calls)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), fastPolicy, func(ctx context.Context) error {
+		calls++
+		return syscall.ECONNRESET
+	})
+
+	if !errors.Is(err, syscall.ECONNRESET) || calls != fastPolicy.MaxAttempts {
+		t.Errorf("Retry() = %v after %d calls, want %d calls", err, calls, fastPolicy.MaxAttempts)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := Retry(ctx, fastPolicy, func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("Retry() = %v after %d calls, want context.Canceled without calls", err, calls)
+	}
+}
+
+func TestRetryCustomShouldRetry(t *testing.T) {
+	policy := fastPolicy
+	policy.ShouldRetry = func(err error) bool { return true }
+
+	calls := 0
+	_ = Retry(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return errors.New("always")
+	})
+
+	if calls != policy.MaxAttempts {
+		t.Errorf("calls = %d, want %d", calls, policy.MaxAttempts)
+	}
+}
+
+func TestNextDelay(t *testing.T) {
+	policy := RetryPolicy{Multiplier: 3, MaxDelay: 100 * time.Millisecond}
+
+	if got := nextDelay(10*time.Millisecond, policy); got != 30*time.Millisecond {
+		t.Errorf("nextDelay() = %v, want 30ms", got)
+	}
+	if got := nextDelay(50*time.Millisecond, policy); got != 100*time.Millisecond {
+		t.Errorf("nextDelay() = %v, want capped 100ms", got)
+	}
+}
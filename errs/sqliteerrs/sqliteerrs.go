@@ -0,0 +1,88 @@
+package sqliteerrs
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/user0608/goones/errs"
+)
+
+// extendedCodes maps SQLite extended result codes to the equivalent Postgres code
+var extendedCodes = map[sqlite3.ErrNoExtended]errs.PGCode{
+	sqlite3.ErrConstraintUnique:     errs.PgDuplicateRecordError,
+	sqlite3.ErrConstraintPrimaryKey: errs.PgDuplicateRecordError,
+	sqlite3.ErrConstraintForeignKey: errs.PgDependentRecordsError,
+	sqlite3.ErrConstraintNotNull:    errs.PgNonNullableFieldsError,
+	sqlite3.ErrConstraintCheck:      errs.PgInvalidFormatError,
+}
+
+// primaryCodes maps SQLite primary result codes to the equivalent Postgres code
+var primaryCodes = map[sqlite3.ErrNo]errs.PGCode{
+	sqlite3.ErrConstraint: errs.PgDataIntegrityError,
+	sqlite3.ErrBusy:       errs.PgLockNotAvailableError,
+	sqlite3.ErrLocked:     errs.PgLockNotAvailableError,
+	sqlite3.ErrNomem:      errs.PgOutOfMemoryError,
+	sqlite3.ErrFull:       errs.PgDiskFullError,
+	sqlite3.ErrReadonly:   errs.PgReadOnlyTransactionError,
+	sqlite3.ErrInterrupt:  errs.PgQueryCanceledError,
+	sqlite3.ErrCantOpen:   errs.PgConnectionFailureError,
+	sqlite3.ErrMismatch:   errs.PgInvalidFieldValueError,
+	sqlite3.ErrTooBig:     "54000",
+	sqlite3.ErrPerm:       errs.PgInsufficientPrivilegeError,
+	sqlite3.ErrAuth:       errs.PgUnauthorizedAccessError,
+	sqlite3.ErrCorrupt:    "XX001",
+	sqlite3.ErrNotADB:     "XX001",
+}
+
+func init() {
+	errs.RegisterDriverAdapter(Adapter)
+}
+
+// Adapter extracts the diagnostic fields of a sqlite3.Error.
+// It is registered in errs when the package is imported.
+func Adapter(err error) (*errs.DBError, bool) {
+	var liteErr sqlite3.Error
+	if !errors.As(err, &liteErr) {
+		var liteErrPtr *sqlite3.Error
+		if !errors.As(err, &liteErrPtr) || liteErrPtr == nil {
+			return nil, false
+		}
+		liteErr = *liteErrPtr
+	}
+
+	dbErr := &errs.DBError{
+		Driver:  "sqlite",
+		Code:    strconv.Itoa(int(liteErr.ExtendedCode)),
+		Message: liteErr.Error(),
+	}
+	if code, ok := extendedCodes[liteErr.ExtendedCode]; ok {
+		dbErr.SQLState = string(code)
+	} else if code, ok := primaryCodes[liteErr.Code]; ok {
+		dbErr.SQLState = string(code)
+	} else {
+		dbErr.SQLState = string(errs.PgClassInternalError) + "000"
+	}
+
+	parseConstraint(dbErr)
+	return dbErr, true
+}
+
+// parseConstraint reads messages like "UNIQUE constraint failed: clientes.email"
+// and "CHECK constraint failed: precio_positivo"
+func parseConstraint(dbErr *errs.DBError) {
+	_, target, ok := strings.Cut(dbErr.Message, "constraint failed: ")
+	if !ok {
+		return
+	}
+	target, _, _ = strings.Cut(target, ",")
+	target = strings.TrimSpace(target)
+
+	if table, column, isColumn := strings.Cut(target, "."); isColumn {
+		dbErr.Table = table
+		dbErr.Column = column
+		return
+	}
+	dbErr.Constraint = target
+}
@@ -0,0 +1,66 @@
+package sqliteerrs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/user0608/goones/errs"
+)
+
+func TestAdapter(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sqlState errs.PGCode
+	}{
+		{"unique", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}, errs.PgDuplicateRecordError},
+		{"primary key", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey}, errs.PgDuplicateRecordError},
+		{"foreign key", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey}, errs.PgDependentRecordsError},
+		{"not null", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintNotNull}, errs.PgNonNullableFieldsError},
+		{"generic constraint", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintTrigger}, errs.PgDataIntegrityError},
+		{"busy", sqlite3.Error{Code: sqlite3.ErrBusy}, errs.PgLockNotAvailableError},
+		{"pointer", &sqlite3.Error{Code: sqlite3.ErrFull}, errs.PgDiskFullError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbErr, ok := Adapter(fmt.Errorf("repository: %w", tt.err))
+			if !ok {
+				t.Fatal("Adapter() = false, want true")
+			}
+			if dbErr.Driver != "sqlite" || errs.PGCode(dbErr.SQLState) != tt.sqlState {
+				t.Errorf("Adapter() = driver %q sqlstate %q, want sqlite %q", dbErr.Driver, dbErr.SQLState, tt.sqlState)
+			}
+		})
+	}
+}
+
+func TestAdapterIgnoresOtherErrors(t *testing.T) {
+	if _, ok := Adapter(errors.New("boom")); ok {
+		t.Errorf("Adapter() = true for non SQLite error")
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	column := &errs.DBError{Message: "UNIQUE constraint failed: clientes.email"}
+	parseConstraint(column)
+	if column.Table != "clientes" || column.Column != "email" {
+		t.Errorf("parseConstraint() = table %q column %q, want clientes email", column.Table, column.Column)
+	}
+
+	check := &errs.DBError{Message: "CHECK constraint failed: precio_positivo"}
+	parseConstraint(check)
+	if check.Constraint != "precio_positivo" {
+		t.Errorf("parseConstraint() = constraint %q, want precio_positivo", check.Constraint)
+	}
+}
+
+func TestDB(t *testing.T) {
+	got := errs.DB(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}).(*errs.Err)
+	if got.Code() != http.StatusBadRequest || got.SQLState() != string(errs.PgDuplicateRecordError) {
+		t.Errorf("DB() = %v, want duplicate record error", got)
+	}
+}
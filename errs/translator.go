@@ -0,0 +1,222 @@
+package errs
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/user0608/ifdevmode"
+)
+
+// TranslateHook is called every time a PgTranslator translates a database error
+type TranslateHook func(source error, translated *Err)
+
+// PgTranslator translates database errors into *Err using its own table of
+// SQLSTATE codes, constraints, dev mode flag and hooks.
+// The package level functions Pgf, DB, AddPgErrs, AddPgConstraint and Devmode
+// delegate to a default instance.
+type PgTranslator struct {
+	mu          sync.RWMutex
+	devmode     bool
+	expose      bool
+	codes       map[PGCode]details
+	constraints map[string]PgConstraint
+	hooks       []TranslateHook
+}
+
+var defaultTranslator = NewPgTranslator()
+
+// NewPgTranslator returns a translator with the default table.
+// Dev mode is enabled when the environment requests it.
+func NewPgTranslator() *PgTranslator {
+	t := &PgTranslator{}
+	t.Reset()
+	return t
+}
+
+// DefaultPgTranslator returns the instance used by the package level functions
+func DefaultPgTranslator() *PgTranslator {
+	return defaultTranslator
+}
+
+// Clone returns an independent copy of the translator
+func (t *PgTranslator) Clone() *PgTranslator {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return &PgTranslator{
+		devmode:     t.devmode,
+		expose:      t.expose,
+		codes:       maps.Clone(t.codes),
+		constraints: maps.Clone(t.constraints),
+		hooks:       slices.Clone(t.hooks),
+	}
+}
+
+// Reset restores the default table and dev mode and removes constraints and hooks
+func (t *PgTranslator) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.devmode = ifdevmode.Yes()
+	t.expose = false
+	t.codes = maps.Clone(defaultPgErrcodes)
+	t.constraints = map[string]PgConstraint{}
+	t.hooks = nil
+}
+
+// Devmode keeps the original driver errors in the translated errors
+func (t *PgTranslator) Devmode() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.devmode = true
+}
+
+func (t *PgTranslator) IsDevmode() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.devmode
+}
+
+// ExposeValues allows the offending values parsed from the database error to reach clients.
+// When enabled the database column is also used as field if no constraint defines one.
+func (t *PgTranslator) ExposeValues(expose bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expose = expose
+}
+
+// AddPgErrs sets the error for a SQLSTATE code, or for a whole class when code has two characters
+func (t *PgTranslator) AddPgErrs(code PGCode, message string, httpCode int, loggable bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.codes[code] = details{message, httpCode, loggable}
+}
+
+// AddPgConstraint registers the error for a constraint name or a "table.column" pair
+func (t *PgTranslator) AddPgConstraint(name string, constraint PgConstraint) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.constraints[name] = constraint
+}
+
+// AddHook registers a function called with every translation
+func (t *PgTranslator) AddHook(hook TranslateHook) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.hooks = append(t.hooks, hook)
+}
+
+// Pgf translates a database error into an *Err, see DB
+func (t *PgTranslator) Pgf(err error) error {
+	return t.DB(err)
+}
+
+// DB translates an error returned by any database driver with a registered adapter into an *Err
+func (t *PgTranslator) DB(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	translated := t.translate(err)
+
+	t.mu.RLock()
+	hooks := t.hooks
+	t.mu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(err, translated)
+	}
+	return translated
+}
+
+func (t *PgTranslator) translate(err error) *Err {
+	if IsNoRows(err) {
+		return ErrRecordNotFound.Wrap(err).(*Err)
+	}
+
+	if ctxErr, ok := FromContext(err); ok {
+		return ctxErr
+	}
+
+	pgerr, ok := ExtractDBError(err)
+	if !ok {
+		return ErrDatabase.Wrap(err).(*Err)
+	}
+
+	result := t.translateDBError(err, pgerr)
+
+	t.mu.RLock()
+	expose := t.expose
+	t.mu.RUnlock()
+
+	if expose {
+		result.exposed = true
+		if result.field == "" {
+			result.field = result.column
+		}
+	}
+	return result
+}
+
+func (t *PgTranslator) translateDBError(err error, pgerr *DBError) *Err {
+	t.mu.RLock()
+	state, ok := t.lookupPgCode(PGCode(pgerr.SQLState))
+	constraint, hasConstraint := t.lookupPgConstraint(pgerr)
+	devmode := t.devmode
+	t.mu.RUnlock()
+
+	if hasConstraint {
+		if !ok {
+			state = details{ErrDatabase.message, ErrDatabase.httpCode, true}
+		}
+		var cause error
+		if state.loggable || devmode {
+			cause = err
+		}
+		return withPgError(constraintError(cause, constraint, state), pgerr)
+	}
+
+	if pgerr.MissingReference {
+		return withPgError(newError(err, message23503, http.StatusBadRequest), pgerr)
+	}
+
+	if !ok {
+		return withPgError(ErrDatabase.Wrap(err), pgerr)
+	}
+
+	if state.loggable || devmode {
+		return withPgError(newError(err, state.message, state.httpCode), pgerr)
+	}
+
+	return withPgError(newError(nil, state.message, state.httpCode), pgerr)
+}
+
+// lookupPgCode returns the entry for code, falling back to its SQLSTATE class.
+// It must be called with t.mu held.
+func (t *PgTranslator) lookupPgCode(code PGCode) (details, bool) {
+	if state, ok := t.codes[code]; ok {
+		return state, true
+	}
+	if len(code) == 5 {
+		state, ok := t.codes[code[:2]]
+		return state, ok
+	}
+	return details{}, false
+}
+
+// lookupPgConstraint must be called with t.mu held
+func (t *PgTranslator) lookupPgConstraint(pgerr *DBError) (PgConstraint, bool) {
+	if pgerr.Constraint != "" {
+		if constraint, ok := t.constraints[pgerr.Constraint]; ok {
+			return constraint, true
+		}
+	}
+	if pgerr.Table != "" && pgerr.Column != "" {
+		if constraint, ok := t.constraints[pgerr.Table+"."+pgerr.Column]; ok {
+			return constraint, true
+		}
+	}
+	return PgConstraint{}, false
+}
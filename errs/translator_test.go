@@ -0,0 +1,95 @@
+package errs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestPgTranslatorInstancesAreIndependent(t *testing.T) {
+	ventas := NewPgTranslator()
+	compras := NewPgTranslator()
+
+	ventas.AddPgErrs(PgDuplicateRecordError, "La venta ya existe.", http.StatusConflict, false)
+
+	pgerr := &pgconn.PgError{Code: string(PgDuplicateRecordError)}
+
+	if got := ventas.Pgf(pgerr).(*Err); got.Message() != "La venta ya existe." || got.Code() != http.StatusConflict {
+		t.Errorf("ventas.Pgf() = %d %q", got.Code(), got.Message())
+	}
+	if got := compras.Pgf(pgerr).(*Err); got.Message() != defaultPgErrcodes[PgDuplicateRecordError].message {
+		t.Errorf("compras.Pgf() = %q, want default message", got.Message())
+	}
+	if got := Pgf(pgerr).(*Err); got.Message() != defaultPgErrcodes[PgDuplicateRecordError].message {
+		t.Errorf("Pgf() = %q, want default message", got.Message())
+	}
+}
+
+func TestPgTranslatorClone(t *testing.T) {
+	original := NewPgTranslator()
+	original.AddPgConstraint("clientes_email_key", PgConstraint{Field: "email"})
+
+	clone := original.Clone()
+	clone.AddPgConstraint("clientes_doc_key", PgConstraint{Field: "doc"})
+
+	pgerr := &pgconn.PgError{Code: string(PgDuplicateRecordError), ConstraintName: "clientes_doc_key"}
+
+	if got := original.DB(pgerr).(*Err); got.Field() != "" {
+		t.Errorf("original.DB() Field() = %q, want empty", got.Field())
+	}
+	if got := clone.DB(pgerr).(*Err); got.Field() != "doc" {
+		t.Errorf("clone.DB() Field() = %q, want doc", got.Field())
+	}
+
+	pgerr.ConstraintName = "clientes_email_key"
+	if got := clone.DB(pgerr).(*Err); got.Field() != "email" {
+		t.Errorf("clone.DB() Field() = %q, want email", got.Field())
+	}
+}
+
+func TestPgTranslatorReset(t *testing.T) {
+	translator := NewPgTranslator()
+	translator.AddPgErrs(PgDuplicateRecordError, "custom", http.StatusConflict, false)
+	translator.AddHook(func(source error, translated *Err) {})
+	translator.Reset()
+
+	got := translator.Pgf(&pgconn.PgError{Code: string(PgDuplicateRecordError)}).(*Err)
+	if got.Message() != defaultPgErrcodes[PgDuplicateRecordError].message {
+		t.Errorf("Pgf() = %q, want default message after Reset", got.Message())
+	}
+	if len(translator.hooks) != 0 {
+		t.Errorf("hooks = %d, want 0 after Reset", len(translator.hooks))
+	}
+}
+
+func TestPgTranslatorDevmode(t *testing.T) {
+	translator := NewPgTranslator()
+	translator.devmode = false
+
+	pgerr := &pgconn.PgError{Code: string(PgDuplicateRecordError)}
+	if got := translator.Pgf(pgerr).(*Err); got.Wrapped() != nil {
+		t.Errorf("Wrapped() = %v, want nil outside dev mode", got.Wrapped())
+	}
+
+	translator.Devmode()
+	if got := translator.Pgf(pgerr).(*Err); got.Wrapped() == nil {
+		t.Errorf("Wrapped() = nil, want original error in dev mode")
+	}
+}
+
+func TestPgTranslatorHooks(t *testing.T) {
+	translator := NewPgTranslator()
+
+	var calls []string
+	translator.AddHook(func(source error, translated *Err) {
+		calls = append(calls, translated.SQLState())
+	})
+
+	translator.Pgf(&pgconn.PgError{Code: string(PgDeadlockDetectedError)})
+	translator.Pgf(nil)
+
+	if len(calls) != 1 || calls[0] != string(PgDeadlockDetectedError) {
+		t.Errorf("hook calls = %v, want one call with %s", calls, PgDeadlockDetectedError)
+	}
+}
@@ -0,0 +1,128 @@
+package kcheck
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+type benchItem struct {
+	SKU      string  `json:"sku" chk:"required alphanum len=8"`
+	Quantity int     `json:"quantity" chk:"gt=0 lte=1000"`
+	Price    float64 `json:"price" chk:"gte=0"`
+}
+
+type benchOrder struct {
+	ID        string      `json:"id" chk:"required uuid"`
+	Email     string      `json:"email" chk:"required email"`
+	Name      string      `json:"name" chk:"required alpha min=2 max=50"`
+	Status    string      `json:"status" chk:"oneof=new,paid,sent"`
+	Website   *string     `json:"website" chk:"url"`
+	CreatedAt time.Time   `json:"created_at" chk:"required utc"`
+	Items     []benchItem `json:"items" chk:"min=1 max=100"`
+	Tags      []string    `json:"tags" chk:"dive required lower"`
+}
+
+func newBenchOrder() benchOrder {
+	website := "https://example.com"
+	items := make([]benchItem, 10)
+	for i := range items {
+		items[i] = benchItem{SKU: "ABCD1234", Quantity: 2, Price: 10.5}
+	}
+
+	return benchOrder{
+		ID:        "8f14e45f-ceea-4672-a8c6-6cbb0d2b2a8f",
+		Email:     "kevin@example.com",
+		Name:      "Kevin",
+		Status:    "paid",
+		Website:   &website,
+		CreatedAt: time.Now().UTC(),
+		Items:     items,
+		Tags:      []string{"uno", "dos", "tres"},
+	}
+}
+
+func BenchmarkStruct(b *testing.B) {
+	v := New()
+	order := newBenchOrder()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := v.Struct(order); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStructUncached drops the plans before every call, like the
+// validation did before plans were cached
+func BenchmarkStructUncached(b *testing.B) {
+	v := New()
+	order := newBenchOrder()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		v.mu.Lock()
+		v.resetPlans()
+		v.mu.Unlock()
+
+		if err := v.Struct(order); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStructParallel(b *testing.B) {
+	v := New()
+	order := newBenchOrder()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := v.Struct(order); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkStructErrors(b *testing.B) {
+	v := New()
+	v.UseTagName("json")
+	order := newBenchOrder()
+	order.Email = "bad"
+	order.Items[3].Quantity = 0
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := v.Struct(order); err == nil {
+			b.Fatal("expected errors")
+		}
+	}
+}
+
+func BenchmarkEmail(b *testing.B) {
+	f := Field{Path: "Email", Value: "kevin@example.com"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := email(f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEmailUncompiled compiles the regexp on every call, like email did
+// before the regexps were compiled once
+func BenchmarkEmailUncompiled(b *testing.B) {
+	f := Field{Path: "Email", Value: "kevin@example.com"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		s, _ := f.Value.(string)
+		if !regexp.MustCompile(emailRegex.String()).MatchString(s) {
+			b.Fatal("expected a valid email")
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Command kcheckgen generates Validate methods for structs with chk tags.
+// The generated code applies the same rules as kcheck without walking the
+// struct with reflection and reports the same kcheck.Errors.
+//
+//	//go:generate go run github.com/user0608/goones/kcheck/cmd/kcheckgen -type Order -names json -test
+//
+// Without -type every struct of the package with a chk tag is generated,
+// together with the structs of the package they contain.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+type config struct {
+	dir    string
+	types  []string
+	names  string
+	output string
+	test   bool
+}
+
+func main() {
+	var cfg config
+	var types string
+
+	flag.StringVar(&cfg.dir, "dir", ".", "directory of the package")
+	flag.StringVar(&types, "type", "", "comma separated struct types, by default every struct with a chk tag")
+	flag.StringVar(&cfg.names, "names", "", "tag used for the field names, like kcheck.UseTagName")
+	flag.StringVar(&cfg.output, "output", "kcheck_gen.go", "output file name inside dir")
+	flag.BoolVar(&cfg.test, "test", false, "also generate a test comparing the generated code with the runtime validation")
+	flag.Parse()
+
+	if types != "" {
+		cfg.types = strings.Split(types, ",")
+	}
+
+	if err := run(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "kcheckgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config) error {
+	code, test, err := generate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(cfg.dir, cfg.output), code, 0o644); err != nil {
+		return err
+	}
+
+	if test == nil {
+		return nil
+	}
+
+	return os.WriteFile(filepath.Join(cfg.dir, testFile(cfg.output)), test, 0o644)
+}
+
+// generate returns the generated code and, with cfg.test, the generated test
+func generate(cfg config) ([]byte, []byte, error) {
+	p, err := parsePackage(cfg.dir, map[string]bool{cfg.output: true, testFile(cfg.output): true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names, err := p.targets(cfg.types, kcheck.DefaultTagName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("no struct with %s tags in %s", kcheck.DefaultTagName, cfg.dir)
+	}
+
+	code, err := render(p, names, kcheck.DefaultTagName, cfg.names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !cfg.test {
+		return code, nil, nil
+	}
+
+	test, err := renderTest(p, names, cfg.names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return code, test, nil
+}
+
+func testFile(output string) string {
+	return strings.TrimSuffix(output, ".go") + "_test.go"
+}
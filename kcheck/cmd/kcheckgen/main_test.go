@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+
+	code, test, err := generate(config{dir: dir, names: "json", output: "kcheck_gen.go", test: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, generated := range map[string][]byte{"kcheck_gen.go": code, "kcheck_gen_test.go": test} {
+		current, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(current, generated) {
+			t.Errorf("%s is out of date, run go generate ./kcheck/internal/gentest", name)
+		}
+	}
+}
+
+func TestGenerateTypes(t *testing.T) {
+	dir := writePackage(t, `package models
+
+type Address struct {
+	City string `+"`chk:\"required\"`"+`
+}
+
+type User struct {
+	Name    string `+"`chk:\"required\"`"+`
+	Address *Address
+}
+
+type Other struct {
+	Code string `+"`chk:\"len=3\"`"+`
+}
+`)
+
+	code, test, err := generate(config{dir: dir, types: []string{"User"}, output: "kcheck_gen.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if test != nil {
+		t.Error("test generated without -test")
+	}
+
+	src := string(code)
+	for _, want := range []string{"func (x *User) ValidateKcheck", "func (x *Address) ValidateKcheck", "x.Address.ValidateKcheck(v, root, p, errs)"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+
+	if strings.Contains(src, "Other") {
+		t.Error("generated code contains a type that was not requested")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := writePackage(t, `package models
+
+type User struct {
+	Name string `+"`chk:\"required\"`"+`
+}
+
+func (u *User) Validate() error { return nil }
+
+type Status string
+`)
+
+	tests := []struct {
+		name  string
+		types []string
+		want  string
+	}{
+		{name: "Validate ya declarado", want: "already declares Validate"},
+		{name: "tipo que no es struct", types: []string{"Status"}, want: "is not a struct"},
+		{name: "tipo inexistente", types: []string{"Missing"}, want: "is not a struct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := generate(config{dir: dir, types: tt.types, output: "kcheck_gen.go"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGenerateParent(t *testing.T) {
+	dir := writePackage(t, `package models
+
+type Range struct {
+	From int `+"`chk:\"gte=0\"`"+`
+	To   int `+"`chk:\"gtfield=From\"`"+`
+}
+
+type Code struct {
+	Value string `+"`chk:\"required len=3\"`"+`
+}
+`)
+
+	code, _, err := generate(config{dir: dir, output: "kcheck_gen.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := string(code)
+	for _, want := range []string{
+		`f := kcheck.Field{Name: "From", Path: p, Value: x.From, Kind: reflect.Int}`,
+		`f := kcheck.Field{Name: "To", Path: p, Value: x.To, Kind: reflect.Int, Parent: parent, Root: root}`,
+		`x.ValidateKcheck(kcheck.Default(), reflect.ValueOf(x).Elem(), "", &errs)`,
+		`x.ValidateKcheck(kcheck.Default(), reflect.Value{}, "", &errs)`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+
+	if strings.Count(src, "parent := reflect.ValueOf(x).Elem()") != 1 {
+		t.Error("expected the parent only in Range")
+	}
+}
+
+func writePackage(t *testing.T, src string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+type shapeKind int
+
+const (
+	shapeOther shapeKind = iota
+	shapeBasic
+	shapeTime
+	shapeStruct
+	shapePointer
+	shapeSlice
+	shapeArray
+	shapeMap
+)
+
+// shape is what the generator knows about the type of a field
+type shape struct {
+	kind shapeKind
+
+	// reflectKind is the name of the reflect.Kind of basic values, e.g. "String"
+	reflectKind string
+
+	// named is the struct type of shapeStruct
+	named string
+
+	elem *shape
+}
+
+// native reports whether the generated code can validate values of s without reflection.
+// Other shapes, e.g. types of other packages or interfaces, are validated by kcheck at runtime.
+func (s *shape) native() bool {
+	switch s.kind {
+	case shapeBasic, shapeTime, shapeStruct:
+		return true
+	case shapePointer:
+		return s.elem.kind == shapeBasic || s.elem.kind == shapeTime || s.elem.kind == shapeStruct
+	case shapeSlice, shapeArray, shapeMap:
+		return s.elem.native()
+	default:
+		return false
+	}
+}
+
+// hasStructs reports whether the elements of a collection are validated without dive
+func (s *shape) hasStructs() bool {
+	elem := s.elem
+	if elem.kind == shapePointer {
+		elem = elem.elem
+	}
+
+	return elem.kind == shapeStruct
+}
+
+type structType struct {
+	name   string
+	fields []structField
+}
+
+type structField struct {
+	goName string
+	name   string
+	rules  []kcheck.CheckRule
+	shape  *shape
+	nested bool
+}
+
+type pkg struct {
+	name    string
+	types   map[string]*ast.TypeSpec
+	methods map[string]map[string]bool
+	imports map[*ast.TypeSpec]map[string]string
+
+	// samples reports whether the package declares kcheckSamples
+	samples bool
+}
+
+var basicKinds = map[string]string{
+	"bool":       "Bool",
+	"string":     "String",
+	"int":        "Int",
+	"int8":       "Int8",
+	"int16":      "Int16",
+	"int32":      "Int32",
+	"int64":      "Int64",
+	"uint":       "Uint",
+	"uint8":      "Uint8",
+	"uint16":     "Uint16",
+	"uint32":     "Uint32",
+	"uint64":     "Uint64",
+	"uintptr":    "Uintptr",
+	"float32":    "Float32",
+	"float64":    "Float64",
+	"complex64":  "Complex64",
+	"complex128": "Complex128",
+	"byte":       "Uint8",
+	"rune":       "Int32",
+}
+
+// parsePackage reads the declarations of the package in dir, skipping the files
+// in exclude. Test files are only searched for kcheckSamples.
+func parsePackage(dir string, exclude map[string]bool) (*pkg, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pkg{
+		types:   make(map[string]*ast.TypeSpec),
+		methods: make(map[string]map[string]bool),
+		imports: make(map[*ast.TypeSpec]map[string]string),
+	}
+
+	fset := token.NewFileSet()
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || exclude[name] {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasSuffix(name, "_test.go") {
+			p.samples = p.samples || declares(file, "kcheckSamples")
+			continue
+		}
+
+		if p.name == "" {
+			p.name = file.Name.Name
+		}
+
+		p.samples = p.samples || declares(file, "kcheckSamples")
+		p.addFile(file)
+	}
+
+	if p.name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	return p, nil
+}
+
+func (p *pkg) addFile(file *ast.File) {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = path
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					p.types[ts.Name.Name] = ts
+					p.imports[ts] = imports
+				}
+			}
+
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) == 0 {
+				continue
+			}
+
+			recv := receiverName(d.Recv.List[0].Type)
+			if p.methods[recv] == nil {
+				p.methods[recv] = make(map[string]bool)
+			}
+
+			p.methods[recv][d.Name.Name] = true
+		}
+	}
+}
+
+func declares(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			for _, ident := range spec.(*ast.ValueSpec).Names {
+				if ident.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	default:
+		return ""
+	}
+}
+
+// structSpec returns the declaration of the struct type name, nil for generic
+// types and types that are not structs.
+func (p *pkg) structSpec(name string) *ast.StructType {
+	ts, ok := p.types[name]
+	if !ok || ts.TypeParams != nil || ts.Assign.IsValid() {
+		return nil
+	}
+
+	st, _ := ts.Type.(*ast.StructType)
+	return st
+}
+
+// targets returns the struct types to generate: the requested ones, or every
+// struct with a tag, and the structs they contain.
+func (p *pkg) targets(requested []string, tag string) ([]string, error) {
+	var queue []string
+
+	if len(requested) > 0 {
+		for _, name := range requested {
+			if p.structSpec(name) == nil {
+				return nil, fmt.Errorf("type %s is not a struct of package %s", name, p.name)
+			}
+
+			queue = append(queue, name)
+		}
+	} else {
+		for name := range p.types {
+			if st := p.structSpec(name); st != nil && hasTag(st, tag) {
+				queue = append(queue, name)
+			}
+		}
+	}
+
+	selected := make(map[string]bool)
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		if selected[name] {
+			continue
+		}
+
+		selected[name] = true
+
+		for _, field := range p.structSpec(name).Fields.List {
+			queue = append(queue, p.localStructs(field.Type, map[string]bool{})...)
+		}
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		if p.methods[name]["Validate"] || p.methods[name]["ValidateKcheck"] {
+			return nil, fmt.Errorf("type %s already declares Validate, it cannot be generated", name)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func hasTag(st *ast.StructType, tag string) bool {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		value, _ := strconv.Unquote(field.Tag.Value)
+		if _, ok := reflect.StructTag(value).Lookup(tag); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// localStructs returns the struct types of the package used by expr
+func (p *pkg) localStructs(expr ast.Expr, seen map[string]bool) []string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if seen[e.Name] {
+			return nil
+		}
+
+		seen[e.Name] = true
+
+		if p.structSpec(e.Name) != nil {
+			return []string{e.Name}
+		}
+
+		if ts, ok := p.types[e.Name]; ok && ts.TypeParams == nil {
+			return p.localStructs(ts.Type, seen)
+		}
+
+	case *ast.StarExpr:
+		return p.localStructs(e.X, seen)
+	case *ast.ArrayType:
+		return p.localStructs(e.Elt, seen)
+	case *ast.MapType:
+		return p.localStructs(e.Value, seen)
+	}
+
+	return nil
+}
+
+func (p *pkg) shapeOf(expr ast.Expr, imports map[string]string, seen map[string]bool) *shape {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if kind, ok := basicKinds[e.Name]; ok && p.types[e.Name] == nil {
+			return &shape{kind: shapeBasic, reflectKind: kind}
+		}
+
+		if p.structSpec(e.Name) != nil {
+			return &shape{kind: shapeStruct, named: e.Name}
+		}
+
+		ts, ok := p.types[e.Name]
+		if !ok || ts.TypeParams != nil || seen[e.Name] {
+			return &shape{kind: shapeOther}
+		}
+
+		seen[e.Name] = true
+		return p.shapeOf(ts.Type, p.imports[ts], seen)
+
+	case *ast.ParenExpr:
+		return p.shapeOf(e.X, imports, seen)
+
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok && imports[x.Name] == "time" && e.Sel.Name == "Time" {
+			return &shape{kind: shapeTime}
+		}
+
+	case *ast.StarExpr:
+		return &shape{kind: shapePointer, elem: p.shapeOf(e.X, imports, seen)}
+
+	case *ast.ArrayType:
+		kind := shapeSlice
+		if e.Len != nil {
+			kind = shapeArray
+		}
+
+		return &shape{kind: kind, elem: p.shapeOf(e.Elt, imports, seen)}
+
+	case *ast.MapType:
+		return &shape{kind: shapeMap, elem: p.shapeOf(e.Value, imports, seen)}
+	}
+
+	return &shape{kind: shapeOther}
+}
+
+// structType reads the fields of name the same way kcheck compiles its plan
+func (p *pkg) structType(name string, tag string, names string) (*structType, error) {
+	ts := p.types[name]
+	st := &structType{name: name}
+
+	for _, field := range ts.Type.(*ast.StructType).Fields.List {
+		var tags reflect.StructTag
+		if field.Tag != nil {
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			tags = reflect.StructTag(value)
+		}
+
+		goNames := make([]string, 0, len(field.Names))
+		for _, ident := range field.Names {
+			goNames = append(goNames, ident.Name)
+		}
+
+		if len(field.Names) == 0 {
+			goNames = append(goNames, embeddedName(field.Type))
+		}
+
+		for _, goName := range goNames {
+			if !ast.IsExported(goName) || tags.Get(tag) == "-" {
+				continue
+			}
+
+			sf := structField{
+				goName: goName,
+				name:   fieldName(goName, tags, names),
+				rules:  kcheck.ParseTag(tags.Get(tag), tags.Get(kcheck.MessageTagName)),
+				shape:  p.shapeOf(field.Type, p.imports[ts], map[string]bool{}),
+			}
+
+			sf.nested = len(p.localStructs(field.Type, map[string]bool{})) > 0 || sf.shape.kind == shapeOther
+
+			if len(sf.rules) == 0 && !sf.nested {
+				continue
+			}
+
+			st.fields = append(st.fields, sf)
+		}
+	}
+
+	return st, nil
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	default:
+		return ""
+	}
+}
+
+// fieldName mirrors kcheck.TagName: the name in the names tag, or the Go name
+func fieldName(goName string, tags reflect.StructTag, names string) string {
+	if names == "" {
+		return goName
+	}
+
+	name, _, _ := strings.Cut(tags.Get(names), ",")
+	if name == "" || name == "-" {
+		return goName
+	}
+
+	return name
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+const kcheckImport = "github.com/user0608/goones/kcheck"
+
+type generator struct {
+	typeName string
+	body     []byte
+	rules    bytes.Buffer
+	count    int
+
+	// usesParent reports whether a field reads its parent, and so may read the root
+	usesParent bool
+
+	// nested are the struct types of the package validated inside the type
+	nested []string
+}
+
+// render returns the generated methods of the struct types names
+func render(p *pkg, names []string, tag string, fieldNames string) ([]byte, error) {
+	generators := make(map[string]*generator, len(names))
+
+	for _, name := range names {
+		st, err := p.structType(name, tag, fieldNames)
+		if err != nil {
+			return nil, err
+		}
+
+		g := &generator{typeName: name}
+		g.body = g.structBody(st)
+		generators[name] = g
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by kcheckgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", p.name)
+	fmt.Fprintf(&buf, "import (\n\t\"reflect\"\n\n\t%q\n)\n", kcheckImport)
+
+	for _, name := range names {
+		g := generators[name]
+
+		// The root is only built for the types whose rules may read it
+		root := "reflect.Value{}"
+		if usesRoot(name, generators, map[string]bool{}) {
+			root = "reflect.ValueOf(x).Elem()"
+		}
+
+		fmt.Fprintf(&buf, "\n// Validate applies the %s rules of %s without walking it with reflection.\n", tag, name)
+		fmt.Fprintf(&buf, "func (x *%s) Validate() error {\n", name)
+		fmt.Fprintf(&buf, "var errs kcheck.Errors\n")
+		fmt.Fprintf(&buf, "x.ValidateKcheck(kcheck.Default(), %s, \"\", &errs)\n", root)
+		fmt.Fprintf(&buf, "return errs.Err()\n}\n")
+
+		fmt.Fprintf(&buf, "\n// ValidateKcheck implements kcheck.Generated.\n")
+		fmt.Fprintf(&buf, "func (x *%s) ValidateKcheck(v *kcheck.Validator, root reflect.Value, path string, errs *kcheck.Errors) {\n", name)
+		if g.usesParent {
+			fmt.Fprintf(&buf, "parent := reflect.ValueOf(x).Elem()\n\n")
+		}
+		buf.Write(g.body)
+		fmt.Fprintf(&buf, "v.CheckStruct(errs, path, x)\n}\n")
+
+		if g.rules.Len() > 0 {
+			fmt.Fprintf(&buf, "\nvar (\n")
+			buf.Write(g.rules.Bytes())
+			fmt.Fprintf(&buf, ")\n")
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// usesRoot reports whether the fields of name, or of the structs validated
+// inside it, may read Field.Root
+func usesRoot(name string, generators map[string]*generator, seen map[string]bool) bool {
+	g, ok := generators[name]
+	if !ok || seen[name] {
+		return false
+	}
+
+	seen[name] = true
+
+	if g.usesParent {
+		return true
+	}
+
+	for _, nested := range g.nested {
+		if usesRoot(nested, generators, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderTest returns a test that compares the generated code with the runtime validation
+func renderTest(p *pkg, names []string, fieldNames string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by kcheckgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", p.name)
+	fmt.Fprintf(&buf, "import (\n\t\"testing\"\n\n\t%q\n)\n\n", kcheckImport)
+
+	fmt.Fprintf(&buf, "func TestKcheckGenerated(t *testing.T) {\n")
+	if fieldNames != "" {
+		fmt.Fprintf(&buf, "v := kcheck.New()\nv.UseTagName(%q)\n\n", fieldNames)
+	} else {
+		fmt.Fprintf(&buf, "v := kcheck.Default()\n\n")
+	}
+
+	fmt.Fprintf(&buf, "values := []kcheck.Generated{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "&%s{},\n", name)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	if p.samples {
+		fmt.Fprintf(&buf, "values = append(values, kcheckSamples...)\n")
+	}
+
+	fmt.Fprintf(&buf, "\nfor _, value := range values {\n")
+	fmt.Fprintf(&buf, "if err := v.VerifyGenerated(value); err != nil {\nt.Error(err)\n}\n}\n}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func (g *generator) structBody(st *structType) []byte {
+	var buf bytes.Buffer
+
+	for _, field := range st.fields {
+		var block bytes.Buffer
+		e := "x." + field.goName
+
+		if field.shape.native() {
+			g.value(&block, e, field.shape, "p", field.name, field.rules, 0)
+		} else {
+			g.usesParent = true
+			fmt.Fprintf(&block, "v.CheckField(errs, root, parent, p, %q, reflect.ValueOf(&%s).Elem(), %s)\n", field.name, e, g.ruleVar(field.rules))
+		}
+
+		if block.Len() == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "{\np := kcheck.JoinPath(path, %q)\n", field.name)
+		buf.Write(block.Bytes())
+		fmt.Fprintf(&buf, "}\n\n")
+	}
+
+	return buf.Bytes()
+}
+
+// value writes the validation of the expression e at the path p in the order
+// of the runtime: the struct itself, the rules and then the elements.
+func (g *generator) value(buf *bytes.Buffer, e string, s *shape, p string, name string, rules []kcheck.CheckRule, depth int) {
+	fieldRules, elemRules, dive := kcheck.SplitDive(rules)
+
+	switch {
+	case s.kind == shapeStruct:
+		g.nested = append(g.nested, s.named)
+		fmt.Fprintf(buf, "%s.ValidateKcheck(v, root, %s, errs)\n", e, p)
+	case s.kind == shapePointer && s.elem.kind == shapeStruct:
+		g.nested = append(g.nested, s.elem.named)
+		fmt.Fprintf(buf, "if %s != nil {\n%s.ValidateKcheck(v, root, %s, errs)\n}\n", e, e, p)
+	}
+
+	if len(fieldRules) > 0 {
+		g.field(buf, e, s, p, name, fieldRules, depth)
+	}
+
+	if s.kind != shapeSlice && s.kind != shapeArray && s.kind != shapeMap {
+		return
+	}
+
+	if !dive && !s.hasStructs() {
+		return
+	}
+
+	n := strconv.Itoa(depth + 1)
+	item, index, elemPath := "item"+n, "i"+n, "p"+n
+
+	var inner bytes.Buffer
+	g.value(&inner, item, s.elem, elemPath, name, elemRules, depth+1)
+	if inner.Len() == 0 {
+		return
+	}
+
+	if s.kind == shapeMap {
+		key := "k" + n
+		fmt.Fprintf(buf, "for _, %s := range kcheck.SortedKeys(%s) {\n", key, e)
+		fmt.Fprintf(buf, "%s := %s[%s]\n", item, e, key)
+		fmt.Fprintf(buf, "%s := kcheck.KeyPath(%s, %s)\n", elemPath, p, key)
+	} else {
+		fmt.Fprintf(buf, "for %s := range %s {\n", index, e)
+		fmt.Fprintf(buf, "%s := %s[%s]\n", item, e, index)
+		fmt.Fprintf(buf, "%s := kcheck.IndexPath(%s, %s)\n", elemPath, p, index)
+	}
+
+	buf.Write(inner.Bytes())
+	fmt.Fprintf(buf, "}\n")
+}
+
+// field writes the kcheck.Field of e, built like the runtime builds it.
+// Parent and Root are only set when a rule may read other fields.
+func (g *generator) field(buf *bytes.Buffer, e string, s *shape, p string, name string, rules []kcheck.CheckRule, depth int) {
+	f := "f"
+	if depth > 0 {
+		f += strconv.Itoa(depth)
+	}
+
+	var others string
+	if !valueRules(rules) {
+		g.usesParent = true
+		others = ", Parent: parent, Root: root"
+	}
+
+	if s.kind != shapePointer {
+		fmt.Fprintf(buf, "%s := kcheck.Field{Name: %q, Path: %s, Value: %s, Kind: reflect.%s%s}\n", f, name, p, e, reflectKind(s), others)
+	} else {
+		fmt.Fprintf(buf, "%s := kcheck.Field{Name: %q, Path: %s, Kind: reflect.Pointer, IsNil: true, IsPointer: true%s}\n", f, name, p, others)
+		fmt.Fprintf(buf, "if %s != nil {\n%s.Value, %s.Kind, %s.IsNil = *%s, reflect.%s, false\n}\n", e, f, f, f, e, reflectKind(s.elem))
+	}
+
+	fmt.Fprintf(buf, "v.Check(errs, %s, %s)\n", f, g.ruleVar(rules))
+}
+
+func reflectKind(s *shape) string {
+	switch s.kind {
+	case shapeBasic:
+		return s.reflectKind
+	case shapeTime, shapeStruct:
+		return "Struct"
+	case shapePointer:
+		return "Pointer"
+	case shapeSlice:
+		return "Slice"
+	case shapeArray:
+		return "Array"
+	case shapeMap:
+		return "Map"
+	default:
+		return "Invalid"
+	}
+}
+
+// valueRules reports whether every rule only reads the value of the field
+func valueRules(rules []kcheck.CheckRule) bool {
+	for _, r := range rules {
+		if !kcheck.ValueRule(r.Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ruleVar declares the rules as a package variable and returns its name
+func (g *generator) ruleVar(rules []kcheck.CheckRule) string {
+	if len(rules) == 0 {
+		return "nil"
+	}
+
+	name := fmt.Sprintf("kcheck%sRules%d", g.typeName, g.count)
+	g.count++
+
+	fmt.Fprintf(&g.rules, "%s = kcheck.NewRules(\n", name)
+	for _, r := range rules {
+		fmt.Fprintf(&g.rules, "kcheck.CheckRule{Name: %q", r.Name)
+		if r.Param != "" {
+			fmt.Fprintf(&g.rules, ", Param: %q", r.Param)
+		}
+		if r.Message != "" {
+			fmt.Fprintf(&g.rules, ", Message: %q", r.Message)
+		}
+		fmt.Fprintf(&g.rules, "},\n")
+	}
+	fmt.Fprintf(&g.rules, ")\n")
+
+	return name
+}
@@ -0,0 +1,113 @@
+package kcheck
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// DefaultMaxConcurrency is the number of context validators run at the same time by a new Validator
+const DefaultMaxConcurrency = 4
+
+type ctxTask struct {
+	field   Field
+	fn      ValidatorCtxFunc
+	at      int
+	redact  bool
+	message string
+}
+
+// RegisterCtx registers a validator that receives the context given to StructCtx.
+// Struct, StructSkip and StructSelect run it with context.Background().
+func (v *Validator) RegisterCtx(name string, fn ValidatorCtxFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.ctxFuncs[name] = fn
+	v.resetPlans()
+}
+
+// SetMaxConcurrency sets how many context validators run at the same time during a validation
+func (v *Validator) SetMaxConcurrency(n int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if n < 1 {
+		n = 1
+	}
+
+	v.maxConcurrency = n
+}
+
+func (v *Validator) StructCtx(ctx context.Context, input any) error {
+	return v.structWithOptions(ctx, input, options{
+		mode:   modeSkip,
+		fields: map[string]struct{}{},
+	})
+}
+
+func (v *Validator) StructSkipCtx(ctx context.Context, input any, skips ...string) error {
+	return v.structWithOptions(ctx, input, options{
+		mode:   modeSkip,
+		fields: toSet(skips),
+	})
+}
+
+func (v *Validator) StructSelectCtx(ctx context.Context, input any, selected ...string) error {
+	return v.structWithOptions(ctx, input, options{
+		mode:   modeSelect,
+		fields: toSet(selected),
+	})
+}
+
+// runCtxTasks runs the context validators found during the walk and inserts
+// their errors where the rule appears, so the order matches the tags.
+// It returns the context error when ctx ends before every validator finishes.
+func (v *Validator) runCtxTasks(ctx context.Context, tasks []ctxTask, errs *Errors) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	v.mu.RLock()
+	limit := v.maxConcurrency
+	v.mu.RUnlock()
+
+	results := make([]error, len(tasks))
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+
+	for i, task := range tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = task.fn(ctx, task.field)
+		}()
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for i := len(tasks) - 1; i >= 0; i-- {
+		if results[i] == nil {
+			continue
+		}
+
+		item := newFieldError(tasks[i].field, v.message(tasks[i].field, tasks[i].message, results[i], tasks[i].redact), tasks[i].redact)
+		errs.Items = slices.Insert(errs.Items, tasks[i].at, item)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package kcheck
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testAccount struct {
+	Name     string   `chk:"required"`
+	Email    string   `chk:"required unique_email"`
+	Category string   `chk:"category_exists"`
+	Tags     []string `chk:"dive category_exists"`
+}
+
+func newCtxValidator(running *atomic.Int32, peak *atomic.Int32) *Validator {
+	v := New()
+	v.SetMaxConcurrency(2)
+
+	slow := func(taken string) ValidatorCtxFunc {
+		return func(ctx context.Context, f Field) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				current := peak.Load()
+				if n <= current || peak.CompareAndSwap(current, n) {
+					break
+				}
+			}
+
+			select {
+			case <-time.After(10 * time.Millisecond):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			if f.Value == taken {
+				return errors.New("valor no permitido")
+			}
+			return nil
+		}
+	}
+
+	v.RegisterCtx("unique_email", slow("usado@example.com"))
+	v.RegisterCtx("category_exists", slow("nope"))
+	return v
+}
+
+func TestStructCtx(t *testing.T) {
+	var running, peak atomic.Int32
+	v := newCtxValidator(&running, &peak)
+
+	account := testAccount{
+		Email:    "usado@example.com",
+		Category: "nope",
+		Tags:     []string{"ok", "nope", "nope"},
+	}
+
+	got := strings.Join(errorFields(v.StructCtx(context.Background(), account)), ",")
+	want := "Name,Email,Category,Tags[1],Tags[2]"
+	if got != want {
+		t.Fatalf("fields = %s, want %s", got, want)
+	}
+
+	if peak.Load() > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak.Load())
+	}
+}
+
+func TestStructCtxCanceled(t *testing.T) {
+	var running, peak atomic.Int32
+	v := newCtxValidator(&running, &peak)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := v.StructCtx(ctx, testAccount{Name: "x", Email: "a@b.com"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestStructRunsCtxValidators(t *testing.T) {
+	var running, peak atomic.Int32
+	v := newCtxValidator(&running, &peak)
+
+	err := v.StructSkip(testAccount{Name: "x", Email: "usado@example.com"}, "Email")
+	if err != nil {
+		t.Fatalf("expected Email skipped, got %v", err)
+	}
+
+	if err := v.Struct(testAccount{Name: "x", Email: "usado@example.com"}); err == nil {
+		t.Fatal("expected unique_email error with context.Background")
+	}
+}
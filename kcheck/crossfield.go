@@ -0,0 +1,367 @@
+package kcheck
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Sibling returns another field of the struct that contains f.
+// Nested fields are written with dots, e.g. "Address.City".
+func (f Field) Sibling(path string) (Field, bool) {
+	return lookupField(f.Parent, path)
+}
+
+// Lookup returns a field of the value being validated, e.g. "Customer.Type"
+func (f Field) Lookup(path string) (Field, bool) {
+	return lookupField(f.Root, path)
+}
+
+func lookupField(rv reflect.Value, path string) (Field, bool) {
+	if !rv.IsValid() || path == "" {
+		return Field{}, false
+	}
+
+	current := rv
+	name := path
+
+	for rest, more := path, true; more; {
+		var part string
+		part, rest, more = strings.Cut(rest, ".")
+
+		current = indirectValue(current)
+		if current.Kind() != reflect.Struct {
+			return Field{}, false
+		}
+
+		sf, ok := current.Type().FieldByName(part)
+		if !ok || sf.PkgPath != "" {
+			return Field{}, false
+		}
+
+		next, err := current.FieldByIndexErr(sf.Index)
+		if err != nil {
+			return Field{}, false
+		}
+
+		current = next
+		name = part
+	}
+
+	return buildField(path, name, current), true
+}
+
+func isPresent(f Field) bool {
+	if f.IsNil || f.Value == nil {
+		return false
+	}
+
+	return !reflect.ValueOf(f.Value).IsZero()
+}
+
+func (v *Validator) registerCrossField() {
+	v.Register("eqfield", equalField)
+	v.Register("nefield", notEqualField)
+	v.Register("gtfield", greaterThanField)
+	v.Register("gtefield", greaterThanOrEqualField)
+	v.Register("ltfield", lessThanField)
+	v.Register("ltefield", lessThanOrEqualField)
+
+	v.Register("required_if", requiredIf)
+	v.Register("required_unless", requiredUnless)
+	v.Register("required_with", requiredWith)
+	v.Register("excluded_with", excludedWith)
+}
+
+func sibling(f Field) (Field, error) {
+	other, ok := f.Sibling(f.Param)
+	if !ok {
+		return Field{}, failWith(f, MsgFieldNotFound, map[string]string{"other": f.Param})
+	}
+
+	return other, nil
+}
+
+func equalField(f Field) error {
+	other, err := sibling(f)
+	if err != nil {
+		return err
+	}
+
+	if !equalValues(f.Value, other.Value) {
+		return Fail(f, MsgEqField)
+	}
+
+	return nil
+}
+
+func notEqualField(f Field) error {
+	other, err := sibling(f)
+	if err != nil {
+		return err
+	}
+
+	if equalValues(f.Value, other.Value) {
+		return Fail(f, MsgNeField)
+	}
+
+	return nil
+}
+
+func greaterThanField(f Field) error {
+	return compareField(f, func(c int) bool { return c > 0 }, MsgGtField)
+}
+
+func greaterThanOrEqualField(f Field) error {
+	return compareField(f, func(c int) bool { return c >= 0 }, MsgGteField)
+}
+
+func lessThanField(f Field) error {
+	return compareField(f, func(c int) bool { return c < 0 }, MsgLtField)
+}
+
+func lessThanOrEqualField(f Field) error {
+	return compareField(f, func(c int) bool { return c <= 0 }, MsgLteField)
+}
+
+func compareField(f Field, accept func(int) bool, key string) error {
+	other, err := sibling(f)
+	if err != nil {
+		return err
+	}
+
+	if f.IsNil || other.IsNil {
+		return nil
+	}
+
+	c, ok := compareValues(f.Value, other.Value)
+	if !ok {
+		return Fail(f, MsgUnsupported)
+	}
+
+	if !accept(c) {
+		return Fail(f, key)
+	}
+
+	return nil
+}
+
+// requiredIf uses the param "Field:value1,value2" and requires f when Field has any of the values
+func requiredIf(f Field) error {
+	matches, err := fieldMatches(f)
+	if err != nil {
+		return err
+	}
+
+	if matches && !isPresent(f) {
+		return failWith(f, MsgRequiredIf, conditionArgs(f))
+	}
+
+	return nil
+}
+
+// requiredUnless uses the param "Field:value1,value2" and requires f unless Field has any of the values
+func requiredUnless(f Field) error {
+	matches, err := fieldMatches(f)
+	if err != nil {
+		return err
+	}
+
+	if !matches && !isPresent(f) {
+		return failWith(f, MsgRequiredUnless, conditionArgs(f))
+	}
+
+	return nil
+}
+
+func fieldMatches(f Field) (bool, error) {
+	name, values, ok := strings.Cut(f.Param, ":")
+	if !ok || name == "" {
+		return false, Fail(f, MsgInvalidParam)
+	}
+
+	other, found := f.Sibling(name)
+	if !found {
+		return false, failWith(f, MsgFieldNotFound, map[string]string{"other": name})
+	}
+
+	if other.IsNil {
+		return false, nil
+	}
+
+	current := fmt.Sprint(other.Value)
+	for _, value := range strings.Split(values, ",") {
+		if current == strings.TrimSpace(value) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func conditionArgs(f Field) map[string]string {
+	name, values, _ := strings.Cut(f.Param, ":")
+	return map[string]string{"other": name, "values": values}
+}
+
+// requiredWith requires f when any of the fields listed in the param is present
+func requiredWith(f Field) error {
+	present, err := anyPresent(f)
+	if err != nil {
+		return err
+	}
+
+	if present != "" && !isPresent(f) {
+		return failWith(f, MsgRequiredWith, map[string]string{"other": present})
+	}
+
+	return nil
+}
+
+// excludedWith rejects f when any of the fields listed in the param is present
+func excludedWith(f Field) error {
+	present, err := anyPresent(f)
+	if err != nil {
+		return err
+	}
+
+	if present != "" && isPresent(f) {
+		return failWith(f, MsgExcludedWith, map[string]string{"other": present})
+	}
+
+	return nil
+}
+
+// anyPresent returns the first field listed in the param that is present
+func anyPresent(f Field) (string, error) {
+	for _, name := range strings.Split(f.Param, ",") {
+		name = strings.TrimSpace(name)
+
+		other, ok := f.Sibling(name)
+		if !ok {
+			return "", failWith(f, MsgFieldNotFound, map[string]string{"other": name})
+		}
+
+		if isPresent(other) {
+			return name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func equalValues(a, b any) bool {
+	if c, ok := compareValues(a, b); ok {
+		return c == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// compareValues compares two numbers, strings or times
+func compareValues(a, b any) (int, bool) {
+	if c, ok := compareNumbers(a, b); ok {
+		return c, true
+	}
+
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+
+		return strings.Compare(x, y), true
+
+	case time.Time:
+		y, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+
+		return x.Compare(y), true
+	}
+
+	return 0, false
+}
+
+// compareNumbers compares integers as integers, so large int64 and uint64
+// values such as IDs keep their precision, and uses float64 only when one of
+// the values is a float.
+func compareNumbers(a, b any) (int, bool) {
+	if x, ok := asInt(a); ok {
+		if y, ok := asInt(b); ok {
+			return cmp.Compare(x, y), true
+		}
+
+		if y, ok := asUint(b); ok {
+			if x < 0 {
+				return -1, true
+			}
+
+			return cmp.Compare(uint64(x), y), true
+		}
+	}
+
+	if x, ok := asUint(a); ok {
+		if y, ok := asUint(b); ok {
+			return cmp.Compare(x, y), true
+		}
+
+		if y, ok := asInt(b); ok {
+			if y < 0 {
+				return 1, true
+			}
+
+			return cmp.Compare(x, uint64(y)), true
+		}
+	}
+
+	x, ok := asFloat(a)
+	if !ok {
+		return 0, false
+	}
+
+	y, ok := asFloat(b)
+	if !ok {
+		return 0, false
+	}
+
+	return cmp.Compare(x, y), true
+}
+
+func asInt(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func asUint(v any) (uint64, bool) {
+	switch n := v.(type) {
+	case uint:
+		return uint64(n), true
+	case uint8:
+		return uint64(n), true
+	case uint16:
+		return uint64(n), true
+	case uint32:
+		return uint64(n), true
+	case uint64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,154 @@
+package kcheck
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPeriod struct {
+	Start time.Time
+	End   time.Time `chk:"gtfield=Start"`
+}
+
+type testSignup struct {
+	Password        string `chk:"required"`
+	PasswordConfirm string `chk:"eqfield=Password"`
+	Username        string `chk:"nefield=Password"`
+	Type            string `chk:"oneof=person,company"`
+	TaxID           string `chk:"required_if=Type:company"`
+	DNI             string `chk:"required_unless=Type:company"`
+	Phone           string
+	PhoneCode       string `chk:"required_with=Phone"`
+	Coupon          string `chk:"excluded_with=Type"`
+	MinAge          int
+	Age             *int `chk:"gtefield=MinAge"`
+	Period          testPeriod
+}
+
+func TestCrossFieldOK(t *testing.T) {
+	age := 20
+	now := time.Now()
+
+	signup := testSignup{
+		Password:        "secreto",
+		PasswordConfirm: "secreto",
+		Username:        "kevin",
+		Type:            "company",
+		TaxID:           "20123456789",
+		MinAge:          18,
+		Age:             &age,
+		Period:          testPeriod{Start: now, End: now.Add(time.Hour)},
+	}
+
+	if err := Valid(signup, "Coupon"); err != nil {
+		t.Fatalf("expected valid signup, got %v", err)
+	}
+}
+
+func TestCrossFieldErrors(t *testing.T) {
+	age := 17
+	now := time.Now()
+
+	signup := testSignup{
+		Password:        "secreto",
+		PasswordConfirm: "otro",
+		Username:        "secreto",
+		Type:            "person",
+		Phone:           "999888777",
+		Coupon:          "PROMO",
+		MinAge:          18,
+		Age:             &age,
+		Period:          testPeriod{Start: now, End: now},
+	}
+
+	got := strings.Join(errorFields(Valid(signup)), ",")
+	want := "PasswordConfirm,Username,DNI,PhoneCode,Coupon,Age,Period.End"
+	if got != want {
+		t.Fatalf("fields = %s, want %s", got, want)
+	}
+}
+
+func TestRequiredIf(t *testing.T) {
+	signup := testSignup{Password: "x", PasswordConfirm: "x", Type: "company", DNI: "1"}
+
+	err := ValidSelect(signup, "TaxID")
+	if err == nil || !strings.Contains(err.Error(), "TaxID") {
+		t.Fatalf("expected TaxID error, got %v", err)
+	}
+}
+
+func TestCrossFieldUnknownField(t *testing.T) {
+	type dto struct {
+		A string `chk:"eqfield=Missing"`
+	}
+
+	if err := Valid(dto{}); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestFieldSiblingAndLookup(t *testing.T) {
+	type address struct {
+		City string
+	}
+
+	type dto struct {
+		Address address
+		Code    string `chk:"city"`
+	}
+
+	v := New()
+	v.Register("city", func(f Field) error {
+		sibling, ok := f.Sibling("Address.City")
+		if !ok || sibling.Value != "Lima" {
+			t.Errorf("Sibling() = %+v, %v", sibling, ok)
+		}
+
+		root, ok := f.Lookup("Code")
+		if !ok || root.Value != "LIM" {
+			t.Errorf("Lookup() = %+v, %v", root, ok)
+		}
+
+		return nil
+	})
+
+	if err := v.Struct(dto{Address: address{City: "Lima"}, Code: "LIM"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrossFieldLargeIntegers(t *testing.T) {
+	type ids struct {
+		ID       int64  `chk:"nefield=ParentID"`
+		ParentID int64  `chk:"ltfield=ID"`
+		Max      uint64 `chk:"gtfield=ID"`
+		Min      int8   `chk:"ltfield=Max"`
+		Ratio    float64
+		Limit    int `chk:"gtefield=Ratio"`
+	}
+
+	value := ids{ID: 9007199254740993, ParentID: 9007199254740992, Max: 18446744073709551615, Min: -1, Ratio: 2.5, Limit: 3}
+	if err := Struct(value); err != nil {
+		t.Fatalf("expected valid ids, got %v", err)
+	}
+
+	value.ParentID = value.ID
+	value.Max = 9007199254740992
+	value.Limit = 2
+
+	err := Struct(value)
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+
+	var fields []string
+	for _, item := range err.(Errors).Items {
+		fields = append(fields, item.Field)
+	}
+
+	want := []string{"ID", "ParentID", "Max", "Limit"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected errors on %v, got %v", want, fields)
+	}
+}
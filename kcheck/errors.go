@@ -2,9 +2,27 @@ package kcheck
 
 import "strings"
 
+// RedactedValue replaces the value of a FieldError unless values are exposed
+const RedactedValue = "[redacted]"
+
+// Codes of the failures that do not come from a rule
+const (
+	CodeUnknownRule = "UNKNOWN_RULE"
+	CodeStruct      = "STRUCT"
+)
+
+// FieldError describes a failed rule. Code is the rule name in upper case,
+// e.g. REQUIRED or REQUIRED_IF, and stays the same when messages change.
+// The JSON keys keep the Go names, as FieldError always marshaled.
 type FieldError struct {
 	Field   string
 	Message string
+	Rule    string `json:",omitempty"`
+	Param   string `json:",omitempty"`
+
+	// Value is RedactedValue unless the Validator exposes values, see ExposeValues
+	Value any    `json:",omitempty"`
+	Code  string `json:",omitempty"`
 }
 
 type Errors struct {
@@ -45,3 +63,27 @@ func (e Errors) Err() error {
 
 	return e
 }
+
+func ruleCode(rule string) string {
+	return strings.ToUpper(rule)
+}
+
+func newFieldError(field Field, message string, redact bool) FieldError {
+	item := FieldError{
+		Field:   field.Path,
+		Message: message,
+		Rule:    field.Tag,
+		Param:   field.Param,
+		Code:    ruleCode(field.Tag),
+	}
+
+	switch {
+	case field.IsNil:
+	case redact:
+		item.Value = RedactedValue
+	default:
+		item.Value = field.Value
+	}
+
+	return item
+}
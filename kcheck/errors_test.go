@@ -0,0 +1,81 @@
+package kcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFieldErrorDetails(t *testing.T) {
+	type dto struct {
+		Name     string `chk:"required"`
+		Age      int    `chk:"gte=18"`
+		Password string `chk:"min=8 redact"`
+		Code     string `chk:"missing=1"`
+	}
+
+	v := New()
+	v.ExposeValues(true)
+
+	err := v.StructSkip(dto{Age: 10, Password: "corto"})
+
+	var fieldErrs Errors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs.Items) != 4 {
+		t.Fatalf("expected four errors, got %v", err)
+	}
+
+	want := []FieldError{
+		{Field: "Name", Rule: "required", Value: "", Code: "REQUIRED"},
+		{Field: "Age", Rule: "gte", Param: "18", Value: 10, Code: "GTE"},
+		{Field: "Password", Rule: "min", Param: "8", Value: RedactedValue, Code: "MIN"},
+		{Field: "Code", Rule: "missing", Param: "1", Code: CodeUnknownRule},
+	}
+
+	for i, item := range fieldErrs.Items {
+		item.Message = ""
+		if item != want[i] {
+			t.Errorf("Items[%d] = %+v, want %+v", i, item, want[i])
+		}
+	}
+}
+
+func TestValuesRedactedByDefault(t *testing.T) {
+	type dto struct {
+		Password string `chk:"min=8"`
+	}
+
+	fieldErrs := Valid(dto{Password: "secret"}).(Errors)
+	if fieldErrs.Items[0].Value != RedactedValue {
+		t.Fatalf("Value = %v, want %s", fieldErrs.Items[0].Value, RedactedValue)
+	}
+
+	data, _ := json.Marshal(fieldErrs)
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("json leaks the value: %s", data)
+	}
+}
+
+func TestErrorsJSON(t *testing.T) {
+	fieldErrs := Errors{Items: []FieldError{{Field: "Age", Message: "menor", Rule: "gte", Param: "18", Value: 10, Code: "GTE"}}}
+
+	data, err := json.Marshal(fieldErrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"Items":[{"Field":"Age","Message":"menor","Rule":"gte","Param":"18","Value":10,"Code":"GTE"}]}`
+	if string(data) != want {
+		t.Fatalf("json = %s, want %s", data, want)
+	}
+
+	var decoded Errors
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Items[0].Code != "GTE" {
+		t.Fatalf("Unmarshal() = %+v, %v", decoded, err)
+	}
+
+	legacy := Errors{Items: []FieldError{{Field: "Name", Message: "requerido"}}}
+	if data, _ := json.Marshal(legacy); string(data) != `{"Items":[{"Field":"Name","Message":"requerido"}]}` {
+		t.Fatalf("legacy json = %s", data)
+	}
+}
@@ -0,0 +1,166 @@
+package kcheck
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync/atomic"
+)
+
+// Generated is implemented by the types with code generated by kcheckgen,
+// root is the value being validated (see Field.Root).
+type Generated interface {
+	ValidateKcheck(v *Validator, root reflect.Value, path string, errs *Errors)
+}
+
+// Rules are the rules of a field of generated code. They are bound to the
+// validators of a Validator the first time they are used and again only
+// after its configuration changes.
+type Rules struct {
+	list  []CheckRule
+	bound atomic.Pointer[boundRules]
+}
+
+type boundRules struct {
+	v          *Validator
+	generation uint64
+	rules      []rule
+}
+
+// NewRules returns the rules of a field, see ParseTag
+func NewRules(rules ...CheckRule) *Rules {
+	return &Rules{list: rules}
+}
+
+// Default returns the Validator used by the package level functions
+func Default() *Validator {
+	return defaultValidator
+}
+
+// Check applies rules to f and appends the failures to errs, like the rules of
+// a struct tag. Context validators run with context.Background().
+func (v *Validator) Check(errs *Errors, f Field, rules *Rules) {
+	opts := options{root: f.Root, redact: v.redactValues()}
+	v.applyRules(f, f.Parent, v.bind(rules), opts, errs)
+}
+
+// CheckField validates with reflection a field whose type has no generated
+// code, e.g. a struct of another package or an interface, like the runtime
+// validation of a struct field.
+func (v *Validator) CheckField(errs *Errors, root reflect.Value, parent reflect.Value, path string, name string, fv reflect.Value, rules *Rules) {
+	opts := options{root: root, redact: v.redactValues()}
+	v.validateValue(fv, parent, path, name, v.bind(rules), opts, errs)
+}
+
+// CheckStruct runs the ValidateStruct method and the functions registered with
+// RegisterStruct of the struct pointed to by value
+func (v *Validator) CheckStruct(errs *Errors, path string, value any) {
+	if sv, ok := value.(StructValidator); ok {
+		v.mergeStructErr(sv.ValidateStruct(), path, options{}, errs)
+	}
+
+	// Struct functions are rare, so the common case skips the reflection
+	if !v.hasStructFuncs.Load() {
+		return
+	}
+
+	rv := indirectValue(reflect.ValueOf(value))
+	if !rv.IsValid() {
+		return
+	}
+
+	v.mu.RLock()
+	funcs := v.structFuncs[rv.Type()]
+	v.mu.RUnlock()
+
+	for _, fn := range funcs {
+		v.mergeStructErr(fn(rv.Interface()), path, options{}, errs)
+	}
+}
+
+// VerifyGenerated compares the errors of the generated code of value with the
+// errors of the runtime validation and describes the first difference.
+func (v *Validator) VerifyGenerated(value Generated) error {
+	var generated Errors
+	value.ValidateKcheck(v, indirectValue(reflect.ValueOf(value)), "", &generated)
+
+	var runtime Errors
+	if err := v.Struct(value); err != nil {
+		fieldErrs, ok := err.(Errors)
+		if !ok {
+			return err
+		}
+
+		runtime = fieldErrs
+	}
+
+	for i := 0; i < len(generated.Items) || i < len(runtime.Items); i++ {
+		if i >= len(generated.Items) {
+			return fmt.Errorf("kcheck: %T: generated code misses %+v", value, runtime.Items[i])
+		}
+
+		if i >= len(runtime.Items) {
+			return fmt.Errorf("kcheck: %T: generated code adds %+v", value, generated.Items[i])
+		}
+
+		if !reflect.DeepEqual(generated.Items[i], runtime.Items[i]) {
+			return fmt.Errorf("kcheck: %T: generated %+v, runtime %+v", value, generated.Items[i], runtime.Items[i])
+		}
+	}
+
+	return nil
+}
+
+// VerifyGenerated compares the generated code of value with the default Validator
+func VerifyGenerated(value Generated) error {
+	return defaultValidator.VerifyGenerated(value)
+}
+
+// bind returns the rules bound to the validators of v. The binding is kept in
+// rules until v changes its configuration or another Validator uses them.
+func (v *Validator) bind(rules *Rules) []rule {
+	if rules == nil {
+		return nil
+	}
+
+	if b := rules.bound.Load(); b != nil && b.v == v && b.generation == v.generation.Load() {
+		return b.rules
+	}
+
+	v.mu.RLock()
+	b := &boundRules{v: v, generation: v.generation.Load(), rules: v.resolveRules(rules.list)}
+	v.mu.RUnlock()
+
+	rules.bound.Store(b)
+	return b.rules
+}
+
+// JoinPath returns the path of the field name inside parent
+func JoinPath(parent string, name string) string {
+	return joinPath(parent, name)
+}
+
+// IndexPath returns the path of the element i of a slice or array
+func IndexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// KeyPath returns the path of the element key of a map
+func KeyPath(path string, key any) string {
+	return fmt.Sprintf("%s[%v]", path, key)
+}
+
+// SortedKeys returns the keys of m in the order used by the runtime validation
+func SortedKeys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b K) int {
+		return compareKeys(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	})
+
+	return slices.Clip(keys)
+}
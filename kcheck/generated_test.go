@@ -0,0 +1,165 @@
+package kcheck
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type generatedDTO struct {
+	Name string `chk:"required min=3"`
+	Tags []string
+	bad  bool
+}
+
+var (
+	generatedDTORules    = NewRules(CheckRule{Name: "required"}, CheckRule{Name: "min", Param: "3"})
+	generatedDTOBadRules = NewRules(CheckRule{Name: "required"})
+)
+
+// ValidateKcheck is written by hand like the code of kcheckgen; bad skips a rule
+func (x *generatedDTO) ValidateKcheck(v *Validator, root reflect.Value, path string, errs *Errors) {
+	rules := generatedDTORules
+	if x.bad {
+		rules = generatedDTOBadRules
+	}
+
+	f := Field{Name: "Name", Path: JoinPath(path, "Name"), Value: x.Name, Kind: reflect.String}
+	v.Check(errs, f, rules)
+	v.CheckStruct(errs, path, x)
+}
+
+func (x *generatedDTO) Validate() error {
+	var errs Errors
+	x.ValidateKcheck(Default(), reflect.Value{}, "", &errs)
+	return errs.Err()
+}
+
+func TestVerifyGenerated(t *testing.T) {
+	v := New()
+
+	if err := v.VerifyGenerated(&generatedDTO{Name: "ab"}); err != nil {
+		t.Fatalf("expected same errors, got %v", err)
+	}
+
+	err := v.VerifyGenerated(&generatedDTO{Name: "ab", bad: true})
+	if err == nil || !strings.Contains(err.Error(), "generated code misses") {
+		t.Fatalf("expected a missing error, got %v", err)
+	}
+}
+
+func TestGeneratedStructFuncs(t *testing.T) {
+	v := New()
+	calls := 0
+	v.RegisterStruct(generatedDTO{}, func(input any) error {
+		calls++
+		return errors.New("total inválido")
+	})
+
+	err := v.Struct(&generatedDTO{Name: "abc"})
+	if err == nil || len(err.(Errors).Items) != 1 || calls != 1 {
+		t.Fatalf("expected only the struct function error, got %v after %d calls", err, calls)
+	}
+
+	var errs Errors
+	(&generatedDTO{Name: "abc"}).ValidateKcheck(v, reflect.Value{}, "dto", &errs)
+	if len(errs.Items) != 1 || errs.Items[0].Field != "dto" || errs.Items[0].Code != CodeStruct {
+		t.Fatalf("expected the struct function error at dto, got %+v", errs.Items)
+	}
+}
+
+func TestCheckContextRule(t *testing.T) {
+	v := New()
+	v.RegisterCtx("taken", func(ctx context.Context, f Field) error {
+		return errors.New("ya existe")
+	})
+
+	var errs Errors
+	v.Check(&errs, Field{Name: "Email", Path: "Email", Value: "a@b.com", Kind: reflect.String}, NewRules(CheckRule{Name: "taken"}, CheckRule{Name: "min", Param: "20"}))
+
+	if len(errs.Items) != 2 || errs.Items[0].Rule != "taken" || errs.Items[1].Rule != "min" {
+		t.Fatalf("expected the context rule in tag order, got %+v", errs.Items)
+	}
+}
+
+func TestCheckField(t *testing.T) {
+	type inner struct {
+		Code string `chk:"len=3"`
+	}
+
+	value := struct {
+		Payload any
+		Items   []inner
+	}{Payload: inner{Code: "x"}, Items: []inner{{Code: "abc"}, {Code: "ab"}}}
+
+	v := New()
+	parent := reflect.ValueOf(value)
+
+	var errs Errors
+	v.CheckField(&errs, parent, parent, "Payload", "Payload", parent.Field(0), NewRules(CheckRule{Name: "required"}))
+	v.CheckField(&errs, parent, parent, "Items", "Items", parent.Field(1), NewRules(CheckRule{Name: "min", Param: "3"}))
+
+	if len(errs.Items) != 2 || errs.Items[0].Field != "Items" || errs.Items[1].Field != "Items[1].Code" {
+		t.Fatalf("unexpected errors %+v", errs.Items)
+	}
+}
+
+func TestGeneratedPaths(t *testing.T) {
+	if got := IndexPath("Items", 2); got != "Items[2]" {
+		t.Errorf("IndexPath: got %s", got)
+	}
+
+	if got := KeyPath("Meta", "k"); got != "Meta[k]" {
+		t.Errorf("KeyPath: got %s", got)
+	}
+
+	if got := JoinPath("Order", "Items"); got != "Order.Items" {
+		t.Errorf("JoinPath: got %s", got)
+	}
+
+	keys := SortedKeys(map[int]string{10: "a", 2: "b", 1: "c"})
+	if !reflect.DeepEqual(keys, []int{1, 2, 10}) {
+		t.Errorf("SortedKeys: expected the numeric order, got %v", keys)
+	}
+
+	anyKeys := SortedKeys(map[any]string{"b": "x", 2: "y", "a": "z"})
+	if !reflect.DeepEqual(anyKeys, []any{2, "a", "b"}) {
+		t.Errorf("SortedKeys: expected the order of fmt.Sprint for mixed keys, got %v", anyKeys)
+	}
+}
+
+func TestRulesBinding(t *testing.T) {
+	rules := NewRules(CheckRule{Name: "startsx"})
+	f := Field{Name: "Code", Path: "Code", Value: "ab", Kind: reflect.String}
+
+	v := New()
+	check := func(v *Validator) FieldError {
+		var errs Errors
+		v.Check(&errs, f, rules)
+		if len(errs.Items) != 1 {
+			t.Fatalf("expected one error, got %+v", errs.Items)
+		}
+		return errs.Items[0]
+	}
+
+	if got := check(v); got.Code != CodeUnknownRule {
+		t.Fatalf("expected an unknown rule, got %+v", got)
+	}
+
+	bound := rules.bound.Load()
+	check(v)
+	if rules.bound.Load() != bound {
+		t.Fatal("expected the rules to stay bound")
+	}
+
+	v.Register("startsx", func(f Field) error { return errors.New("debe empezar con x") })
+	if got := check(v); got.Code != "STARTSX" {
+		t.Fatalf("expected the rules bound again after Register, got %+v", got)
+	}
+
+	if got := check(New()); got.Code != CodeUnknownRule {
+		t.Fatalf("expected the rules bound to the other Validator, got %+v", got)
+	}
+}
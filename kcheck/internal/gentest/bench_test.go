@@ -0,0 +1,43 @@
+package gentest
+
+import (
+	"testing"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+func BenchmarkGenerated(b *testing.B) {
+	order := kcheckSamples[1].(*Order)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = order.Validate()
+	}
+}
+
+func BenchmarkRuntime(b *testing.B) {
+	order := kcheckSamples[1].(*Order)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = kcheck.Struct(order)
+	}
+}
+
+func BenchmarkGeneratedSmall(b *testing.B) {
+	line := &Line{SKU: "A1", Quantity: 1, Price: 10}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = line.Validate()
+	}
+}
+
+func BenchmarkRuntimeSmall(b *testing.B) {
+	line := &Line{SKU: "A1", Quantity: 1, Price: 10}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = kcheck.Struct(line)
+	}
+}
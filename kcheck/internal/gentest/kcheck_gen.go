@@ -0,0 +1,361 @@
+// Code generated by kcheckgen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"reflect"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+// Validate applies the chk rules of Address without walking it with reflection.
+func (x *Address) Validate() error {
+	var errs kcheck.Errors
+	x.ValidateKcheck(kcheck.Default(), reflect.Value{}, "", &errs)
+	return errs.Err()
+}
+
+// ValidateKcheck implements kcheck.Generated.
+func (x *Address) ValidateKcheck(v *kcheck.Validator, root reflect.Value, path string, errs *kcheck.Errors) {
+	{
+		p := kcheck.JoinPath(path, "city")
+		f := kcheck.Field{Name: "city", Path: p, Value: x.City, Kind: reflect.String}
+		v.Check(errs, f, kcheckAddressRules0)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "zip_code")
+		f := kcheck.Field{Name: "zip_code", Path: p, Kind: reflect.Pointer, IsNil: true, IsPointer: true}
+		if x.ZipCode != nil {
+			f.Value, f.Kind, f.IsNil = *x.ZipCode, reflect.String, false
+		}
+		v.Check(errs, f, kcheckAddressRules1)
+	}
+
+	v.CheckStruct(errs, path, x)
+}
+
+var (
+	kcheckAddressRules0 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "min", Param: "3"},
+	)
+	kcheckAddressRules1 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "len", Param: "5"},
+		kcheck.CheckRule{Name: "num"},
+	)
+)
+
+// Validate applies the chk rules of Base without walking it with reflection.
+func (x *Base) Validate() error {
+	var errs kcheck.Errors
+	x.ValidateKcheck(kcheck.Default(), reflect.Value{}, "", &errs)
+	return errs.Err()
+}
+
+// ValidateKcheck implements kcheck.Generated.
+func (x *Base) ValidateKcheck(v *kcheck.Validator, root reflect.Value, path string, errs *kcheck.Errors) {
+	{
+		p := kcheck.JoinPath(path, "id")
+		f := kcheck.Field{Name: "id", Path: p, Value: x.ID, Kind: reflect.String}
+		v.Check(errs, f, kcheckBaseRules0)
+	}
+
+	v.CheckStruct(errs, path, x)
+}
+
+var (
+	kcheckBaseRules0 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "uuid"},
+	)
+)
+
+// Validate applies the chk rules of Line without walking it with reflection.
+func (x *Line) Validate() error {
+	var errs kcheck.Errors
+	x.ValidateKcheck(kcheck.Default(), reflect.Value{}, "", &errs)
+	return errs.Err()
+}
+
+// ValidateKcheck implements kcheck.Generated.
+func (x *Line) ValidateKcheck(v *kcheck.Validator, root reflect.Value, path string, errs *kcheck.Errors) {
+	{
+		p := kcheck.JoinPath(path, "sku")
+		f := kcheck.Field{Name: "sku", Path: p, Value: x.SKU, Kind: reflect.String}
+		v.Check(errs, f, kcheckLineRules0)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "quantity")
+		f := kcheck.Field{Name: "quantity", Path: p, Value: x.Quantity, Kind: reflect.Int}
+		v.Check(errs, f, kcheckLineRules1)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "price")
+		f := kcheck.Field{Name: "price", Path: p, Value: x.Price, Kind: reflect.Float64}
+		v.Check(errs, f, kcheckLineRules2)
+	}
+
+	v.CheckStruct(errs, path, x)
+}
+
+var (
+	kcheckLineRules0 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "upper"},
+	)
+	kcheckLineRules1 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "gt", Param: "0"},
+	)
+	kcheckLineRules2 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "gte", Param: "0"},
+	)
+)
+
+// Validate applies the chk rules of Order without walking it with reflection.
+func (x *Order) Validate() error {
+	var errs kcheck.Errors
+	x.ValidateKcheck(kcheck.Default(), reflect.ValueOf(x).Elem(), "", &errs)
+	return errs.Err()
+}
+
+// ValidateKcheck implements kcheck.Generated.
+func (x *Order) ValidateKcheck(v *kcheck.Validator, root reflect.Value, path string, errs *kcheck.Errors) {
+	parent := reflect.ValueOf(x).Elem()
+
+	{
+		p := kcheck.JoinPath(path, "Base")
+		x.Base.ValidateKcheck(v, root, p, errs)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "customer")
+		f := kcheck.Field{Name: "customer", Path: p, Value: x.Customer, Kind: reflect.String}
+		v.Check(errs, f, kcheckOrderRules0)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "email")
+		f := kcheck.Field{Name: "email", Path: p, Kind: reflect.Pointer, IsNil: true, IsPointer: true}
+		if x.Email != nil {
+			f.Value, f.Kind, f.IsNil = *x.Email, reflect.String, false
+		}
+		v.Check(errs, f, kcheckOrderRules1)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "Password")
+		f := kcheck.Field{Name: "Password", Path: p, Value: x.Password, Kind: reflect.String}
+		v.Check(errs, f, kcheckOrderRules2)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "confirm")
+		f := kcheck.Field{Name: "confirm", Path: p, Value: x.Confirm, Kind: reflect.String, Parent: parent, Root: root}
+		v.Check(errs, f, kcheckOrderRules3)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "status")
+		f := kcheck.Field{Name: "status", Path: p, Value: x.Status, Kind: reflect.String}
+		v.Check(errs, f, kcheckOrderRules4)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "kind")
+		f := kcheck.Field{Name: "kind", Path: p, Value: x.Kind, Kind: reflect.String}
+		v.Check(errs, f, kcheckOrderRules5)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "ruc")
+		f := kcheck.Field{Name: "ruc", Path: p, Value: x.RUC, Kind: reflect.String, Parent: parent, Root: root}
+		v.Check(errs, f, kcheckOrderRules6)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "created_at")
+		f := kcheck.Field{Name: "created_at", Path: p, Value: x.CreatedAt, Kind: reflect.Struct}
+		v.Check(errs, f, kcheckOrderRules7)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "due_at")
+		f := kcheck.Field{Name: "due_at", Path: p, Kind: reflect.Pointer, IsNil: true, IsPointer: true, Parent: parent, Root: root}
+		if x.DueAt != nil {
+			f.Value, f.Kind, f.IsNil = *x.DueAt, reflect.Struct, false
+		}
+		v.Check(errs, f, kcheckOrderRules8)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "address")
+		x.Address.ValidateKcheck(v, root, p, errs)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "billing")
+		if x.Billing != nil {
+			x.Billing.ValidateKcheck(v, root, p, errs)
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "lines")
+		f := kcheck.Field{Name: "lines", Path: p, Value: x.Lines, Kind: reflect.Slice}
+		v.Check(errs, f, kcheckOrderRules9)
+		for i1 := range x.Lines {
+			item1 := x.Lines[i1]
+			p1 := kcheck.IndexPath(p, i1)
+			item1.ValidateKcheck(v, root, p1, errs)
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "extra")
+		for i1 := range x.Extra {
+			item1 := x.Extra[i1]
+			p1 := kcheck.IndexPath(p, i1)
+			if item1 != nil {
+				item1.ValidateKcheck(v, root, p1, errs)
+			}
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "tags")
+		f := kcheck.Field{Name: "tags", Path: p, Value: x.Tags, Kind: reflect.Slice}
+		v.Check(errs, f, kcheckOrderRules10)
+		for i1 := range x.Tags {
+			item1 := x.Tags[i1]
+			p1 := kcheck.IndexPath(p, i1)
+			f1 := kcheck.Field{Name: "tags", Path: p1, Value: item1, Kind: reflect.String}
+			v.Check(errs, f1, kcheckOrderRules11)
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "scores")
+		for _, k1 := range kcheck.SortedKeys(x.Scores) {
+			item1 := x.Scores[k1]
+			p1 := kcheck.KeyPath(p, k1)
+			f1 := kcheck.Field{Name: "scores", Path: p1, Value: item1, Kind: reflect.Int}
+			v.Check(errs, f1, kcheckOrderRules12)
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "matrix")
+		for i1 := range x.Matrix {
+			item1 := x.Matrix[i1]
+			p1 := kcheck.IndexPath(p, i1)
+			f1 := kcheck.Field{Name: "matrix", Path: p1, Value: item1, Kind: reflect.Slice}
+			v.Check(errs, f1, kcheckOrderRules13)
+			for i2 := range item1 {
+				item2 := item1[i2]
+				p2 := kcheck.IndexPath(p1, i2)
+				f2 := kcheck.Field{Name: "matrix", Path: p2, Value: item2, Kind: reflect.Int}
+				v.Check(errs, f2, kcheckOrderRules14)
+			}
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "by_city")
+		for _, k1 := range kcheck.SortedKeys(x.ByCity) {
+			item1 := x.ByCity[k1]
+			p1 := kcheck.KeyPath(p, k1)
+			item1.ValidateKcheck(v, root, p1, errs)
+		}
+	}
+
+	{
+		p := kcheck.JoinPath(path, "timeout")
+		v.CheckField(errs, root, parent, p, "timeout", reflect.ValueOf(&x.Timeout).Elem(), kcheckOrderRules15)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "payload")
+		v.CheckField(errs, root, parent, p, "payload", reflect.ValueOf(&x.Payload).Elem(), kcheckOrderRules16)
+	}
+
+	{
+		p := kcheck.JoinPath(path, "codes")
+		for i1 := range x.Codes {
+			item1 := x.Codes[i1]
+			p1 := kcheck.IndexPath(p, i1)
+			f1 := kcheck.Field{Name: "codes", Path: p1, Value: item1, Kind: reflect.String}
+			v.Check(errs, f1, kcheckOrderRules17)
+		}
+	}
+
+	v.CheckStruct(errs, path, x)
+}
+
+var (
+	kcheckOrderRules0 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required", Message: "Ingrese el cliente"},
+		kcheck.CheckRule{Name: "min", Param: "2"},
+		kcheck.CheckRule{Name: "max", Param: "50"},
+	)
+	kcheckOrderRules1 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "email"},
+	)
+	kcheckOrderRules2 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "min", Param: "8"},
+		kcheck.CheckRule{Name: "redact"},
+	)
+	kcheckOrderRules3 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "eqfield", Param: "Password"},
+		kcheck.CheckRule{Name: "redact"},
+	)
+	kcheckOrderRules4 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "oneof", Param: "draft,paid"},
+	)
+	kcheckOrderRules5 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "oneof", Param: "person,company"},
+	)
+	kcheckOrderRules6 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required_if", Param: "Kind:company"},
+	)
+	kcheckOrderRules7 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "utc"},
+	)
+	kcheckOrderRules8 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "gtfield", Param: "CreatedAt"},
+	)
+	kcheckOrderRules9 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "min", Param: "1"},
+	)
+	kcheckOrderRules10 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "max", Param: "3"},
+	)
+	kcheckOrderRules11 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+		kcheck.CheckRule{Name: "lower"},
+	)
+	kcheckOrderRules12 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "gte", Param: "0"},
+		kcheck.CheckRule{Name: "lte", Param: "10"},
+	)
+	kcheckOrderRules13 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "min", Param: "1"},
+	)
+	kcheckOrderRules14 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "gte", Param: "0"},
+	)
+	kcheckOrderRules15 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "oneof", Param: "0s,1s,5s"},
+	)
+	kcheckOrderRules16 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "required"},
+	)
+	kcheckOrderRules17 = kcheck.NewRules(
+		kcheck.CheckRule{Name: "alphanum"},
+	)
+)
@@ -0,0 +1,28 @@
+// Code generated by kcheckgen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"testing"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+func TestKcheckGenerated(t *testing.T) {
+	v := kcheck.New()
+	v.UseTagName("json")
+
+	values := []kcheck.Generated{
+		&Address{},
+		&Base{},
+		&Line{},
+		&Order{},
+	}
+	values = append(values, kcheckSamples...)
+
+	for _, value := range values {
+		if err := v.VerifyGenerated(value); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package gentest holds the structs used to check the code generated by kcheckgen
+package gentest
+
+import (
+	"time"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+//go:generate go run ../../cmd/kcheckgen -names json -test
+
+type Status string
+
+type Base struct {
+	ID string `json:"id" chk:"required uuid"`
+}
+
+type Address struct {
+	City    string  `json:"city" chk:"required min=3"`
+	ZipCode *string `json:"zip_code" chk:"len=5 num"`
+}
+
+type Line struct {
+	SKU      string  `json:"sku" chk:"required upper"`
+	Quantity int     `json:"quantity" chk:"gt=0"`
+	Price    float64 `json:"price" chk:"gte=0"`
+}
+
+type Lines []Line
+
+type Order struct {
+	Base
+	Customer   string             `json:"customer" chk:"required min=2 max=50" chkmsg:"required=Ingrese el cliente"`
+	Email      *string            `json:"email" chk:"required email"`
+	Password   string             `json:"-" chk:"required min=8 redact"`
+	Confirm    string             `json:"confirm" chk:"eqfield=Password redact"`
+	Status     Status             `json:"status" chk:"oneof=draft,paid"`
+	Kind       string             `json:"kind" chk:"oneof=person,company"`
+	RUC        string             `json:"ruc" chk:"required_if=Kind:company"`
+	CreatedAt  time.Time          `json:"created_at" chk:"required utc"`
+	DueAt      *time.Time         `json:"due_at" chk:"gtfield=CreatedAt"`
+	Address    Address            `json:"address"`
+	Billing    *Address           `json:"billing"`
+	Lines      Lines              `json:"lines" chk:"min=1"`
+	Extra      []*Line            `json:"extra"`
+	Tags       []string           `json:"tags" chk:"max=3 dive required lower"`
+	Scores     map[string]int     `json:"scores" chk:"dive gte=0 lte=10"`
+	Matrix     [][]int            `json:"matrix" chk:"dive min=1 dive gte=0"`
+	ByCity     map[string]Address `json:"by_city"`
+	Timeout    time.Duration      `json:"timeout" chk:"oneof=0s,1s,5s"`
+	Payload    any                `json:"payload" chk:"required"`
+	Codes      [2]string          `json:"codes" chk:"dive alphanum"`
+	unexported string             `chk:"required"`
+}
+
+// ValidateStruct requires the zip code of the addresses in Lima
+func (a *Address) ValidateStruct() error {
+	var errs kcheck.Errors
+	if a.City == "Lima" && a.ZipCode == nil {
+		errs.Add("zip_code", "el código postal es requerido en Lima")
+	}
+
+	return errs.Err()
+}
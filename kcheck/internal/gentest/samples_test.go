@@ -0,0 +1,50 @@
+package gentest
+
+import (
+	"time"
+
+	"github.com/user0608/goones/kcheck"
+)
+
+var kcheckSamples = []kcheck.Generated{
+	&Order{
+		Base:      Base{ID: "nope"},
+		Customer:  "A",
+		Email:     ptr("bad"),
+		Password:  "short",
+		Confirm:   "other",
+		Status:    "lost",
+		Kind:      "company",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("PET", -5*3600)),
+		DueAt:     ptr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Address:   Address{City: "Li", ZipCode: ptr("12a")},
+		Billing:   &Address{},
+		Lines:     Lines{{SKU: "ok", Quantity: 0, Price: -1}, {SKU: "A1", Quantity: 1}},
+		Extra:     []*Line{nil, {Quantity: -1}},
+		Tags:      []string{"a", "", "B", "c"},
+		Scores:    map[string]int{"b": 11, "a": -1, "c": 5},
+		Matrix:    [][]int{{1, -2}, {}},
+		ByCity:    map[string]Address{"lima": {City: "Lima"}, "cusco": {}},
+		Timeout:   -time.Second,
+		Codes:     [2]string{"ok", "no!"},
+	},
+	&Order{
+		Base:      Base{ID: "1b4e28ba-2fa1-41d2-883f-0016d3cca427"},
+		Customer:  "Kevin",
+		Email:     ptr("kevin@example.com"),
+		Password:  "secret123",
+		Confirm:   "secret123",
+		Status:    "paid",
+		Kind:      "company",
+		RUC:       "20123456789",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Address:   Address{City: "Lima", ZipCode: ptr("15001")},
+		Lines:     Lines{{SKU: "A1", Quantity: 1, Price: 10}},
+		Payload:   map[string]any{"a": 1},
+		Codes:     [2]string{"a1", "b2"},
+	},
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -1,20 +1,31 @@
 package kcheck
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const DefaultTagName = "chk"
 
+// MessageTagName is the tag with the custom messages of a field, see parseMessages
+const MessageTagName = "chkmsg"
+
 var ErrInvalidInput = errors.New("kcheck: invalid input")
 
 type ValidatorFunc func(Field) error
 
+// ValidatorCtxFunc is a validator that needs a context, e.g. to query a database.
+// They run after the field walk, at most MaxConcurrency at a time.
+type ValidatorCtxFunc func(ctx context.Context, f Field) error
+
 type Field struct {
 	Name      string
 	Path      string
@@ -24,12 +35,30 @@ type Field struct {
 	Kind      reflect.Kind
 	IsNil     bool
 	IsPointer bool
+
+	// Parent is the struct that contains the field and Root the value being validated.
+	// They give custom validators access to other fields, see Sibling and Lookup.
+	Parent reflect.Value
+	Root   reflect.Value
 }
 
 type Validator struct {
-	mu    sync.RWMutex
-	tag   string
-	funcs map[string]ValidatorFunc
+	mu             sync.RWMutex
+	tag            string
+	funcs          map[string]ValidatorFunc
+	ctxFuncs       map[string]ValidatorCtxFunc
+	structFuncs    map[reflect.Type][]StructFunc
+	nameFunc       NameFunc
+	locale         string
+	messages       map[string]string
+	maxConcurrency int
+	plans          sync.Map
+	vars           sync.Map
+
+	// Read without mu by the generated code
+	expose         atomic.Bool
+	hasStructFuncs atomic.Bool
+	generation     atomic.Uint64
 }
 
 type mode int
@@ -42,12 +71,18 @@ const (
 type options struct {
 	mode   mode
 	fields map[string]struct{}
+	root   reflect.Value
+	tasks  *[]ctxTask
+	redact bool
 }
 
 func New() *Validator {
 	v := &Validator{
-		tag:   DefaultTagName,
-		funcs: make(map[string]ValidatorFunc),
+		tag:            DefaultTagName,
+		funcs:          make(map[string]ValidatorFunc),
+		ctxFuncs:       make(map[string]ValidatorCtxFunc),
+		locale:         DefaultLocale,
+		maxConcurrency: DefaultMaxConcurrency,
 	}
 
 	v.RegisterDefaults()
@@ -59,38 +94,31 @@ func (v *Validator) Register(name string, fn ValidatorFunc) {
 	defer v.mu.Unlock()
 
 	v.funcs[name] = fn
-}
-
-func (v *Validator) get(name string) (ValidatorFunc, bool) {
-	v.mu.RLock()
-	defer v.mu.RUnlock()
-
-	fn, ok := v.funcs[name]
-	return fn, ok
+	v.resetPlans()
 }
 
 func (v *Validator) Struct(input any) error {
-	return v.structWithOptions(input, options{
+	return v.structWithOptions(context.Background(), input, options{
 		mode:   modeSkip,
 		fields: map[string]struct{}{},
 	})
 }
 
 func (v *Validator) StructSkip(input any, skips ...string) error {
-	return v.structWithOptions(input, options{
+	return v.structWithOptions(context.Background(), input, options{
 		mode:   modeSkip,
 		fields: toSet(skips),
 	})
 }
 
 func (v *Validator) StructSelect(input any, selected ...string) error {
-	return v.structWithOptions(input, options{
+	return v.structWithOptions(context.Background(), input, options{
 		mode:   modeSelect,
 		fields: toSet(selected),
 	})
 }
 
-func (v *Validator) structWithOptions(input any, opts options) error {
+func (v *Validator) structWithOptions(ctx context.Context, input any, opts options) error {
 	if input == nil {
 		return ErrInvalidInput
 	}
@@ -109,73 +137,182 @@ func (v *Validator) structWithOptions(input any, opts options) error {
 		return ErrInvalidInput
 	}
 
+	opts.root = rv
+	opts.tasks = &[]ctxTask{}
+	opts.redact = v.redactValues()
+
 	var errs Errors
 	v.validateStruct(rv, "", opts, &errs)
 
+	if err := v.runCtxTasks(ctx, *opts.tasks, &errs); err != nil {
+		return err
+	}
+
 	return errs.Err()
 }
 
 func (v *Validator) validateStruct(rv reflect.Value, parentPath string, opts options, errs *Errors) {
-	rt := rv.Type()
+	plan := v.plan(rv.Type())
 
-	for i := 0; i < rv.NumField(); i++ {
-		sf := rt.Field(i)
-		fv := rv.Field(i)
+	for i := range plan.fields {
+		fp := &plan.fields[i]
+		fv := rv.Field(fp.index)
 
-		if sf.PkgPath != "" {
-			continue
-		}
+		path := joinPath(parentPath, fp.name)
 
-		fieldName := sf.Name
+		ignored := shouldIgnore(fp.name, path, opts)
 
-		path := fieldName
-		if parentPath != "" {
-			path = parentPath + "." + fieldName
+		if ignored && !shouldDiveForSelectedPath(path, opts) {
+			continue
 		}
 
-		ignored := shouldIgnore(fieldName, path, opts)
+		if fp.nested && shouldDive(fv) {
+			v.validateStruct(indirectValue(fv), path, opts, errs)
+		}
 
-		if ignored && !shouldDiveForSelectedPath(path, opts) {
-			continue
+		if !ignored && len(fp.fieldRules) > 0 {
+			v.applyRules(buildField(path, fp.name, fv), rv, fp.fieldRules, opts, errs)
 		}
 
-		tag := sf.Tag.Get(v.tag)
-		if tag == "-" {
-			continue
+		if fp.dive || fp.nested {
+			v.validateElements(fv, rv, path, fp.name, fp.elemRules, fp.dive, ignored, opts, errs)
 		}
+	}
 
-		if shouldDive(fv) {
-			v.validateStruct(indirectValue(fv), path, opts, errs)
+	v.validateStructLevel(rv, plan, parentPath, opts, errs)
+}
+
+// validateElements applies the rules written after dive to every element of a
+// slice, array or map and validates the elements that are structs.
+func (v *Validator) validateElements(rv reflect.Value, parent reflect.Value, path string, name string, rules []rule, dive bool, ignored bool, opts options, errs *Errors) {
+	rv = indirectValue(rv)
+
+	if !rv.IsValid() || (!dive && !hasStructElements(rv)) {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			v.validateElement(rv.Index(i), parent, fmt.Sprintf("%s[%d]", path, i), name, rules, ignored, opts, errs)
 		}
 
-		if ignored {
-			continue
+	case reflect.Map:
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, compareKeys)
+
+		for _, key := range keys {
+			v.validateElement(rv.MapIndex(key), parent, fmt.Sprintf("%s[%v]", path, key.Interface()), name, rules, ignored, opts, errs)
 		}
+	}
+}
 
-		if tag == "" {
+// compareKeys orders map keys: integers, floats and strings by their value
+// and any other key by its fmt.Sprint form.
+func compareKeys(a, b reflect.Value) int {
+	x, y := a, b
+	if x.Kind() == reflect.Interface {
+		x = x.Elem()
+	}
+	if y.Kind() == reflect.Interface {
+		y = y.Elem()
+	}
+
+	switch {
+	case x.CanInt() && y.CanInt():
+		return cmp.Compare(x.Int(), y.Int())
+	case x.CanUint() && y.CanUint():
+		return cmp.Compare(x.Uint(), y.Uint())
+	case x.CanFloat() && y.CanFloat():
+		return cmp.Compare(x.Float(), y.Float())
+	case x.Kind() == reflect.String && y.Kind() == reflect.String:
+		return strings.Compare(x.String(), y.String())
+	}
+
+	return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+func (v *Validator) validateElement(ev reflect.Value, parent reflect.Value, path string, name string, rules []rule, ignored bool, opts options, errs *Errors) {
+	if ev.Kind() == reflect.Interface && !ev.IsNil() {
+		ev = ev.Elem()
+	}
+
+	elemRules, nestedRules, dive := splitDive(rules)
+
+	if shouldDive(ev) {
+		v.validateStruct(indirectValue(ev), path, opts, errs)
+	}
+
+	if !ignored && len(elemRules) > 0 {
+		v.applyRules(buildField(path, name, ev), parent, elemRules, opts, errs)
+	}
+
+	v.validateElements(ev, parent, path, name, nestedRules, dive, ignored, opts, errs)
+}
+
+// validateValue validates a value that is not reached through a struct plan,
+// in the order of validateStruct: the struct itself, the rules and the elements.
+func (v *Validator) validateValue(rv reflect.Value, parent reflect.Value, path string, name string, rules []rule, opts options, errs *Errors) {
+	fieldRules, elemRules, dive := splitDive(rules)
+
+	if shouldDive(rv) {
+		v.validateStruct(indirectValue(rv), path, opts, errs)
+	}
+
+	if len(fieldRules) > 0 {
+		v.applyRules(buildField(path, name, rv), parent, fieldRules, opts, errs)
+	}
+
+	v.validateElements(rv, parent, path, name, elemRules, dive, false, opts, errs)
+}
+
+func (v *Validator) applyRules(field Field, parent reflect.Value, rules []rule, opts options, errs *Errors) {
+	field.Parent = parent
+	field.Root = opts.root
+
+	redact := opts.redact || slices.ContainsFunc(rules, func(r rule) bool { return r.Name == "redact" })
+
+	for _, rule := range rules {
+		if rule.Name == "redact" {
 			continue
 		}
 
-		field := buildField(path, fieldName, fv)
+		field.Tag = rule.Name
+		field.Param = rule.Param
+
+		if rule.fn == nil {
+			if rule.ctxFn != nil {
+				task := ctxTask{field: field, fn: rule.ctxFn, at: len(errs.Items), redact: redact, message: rule.Message}
 
-		for _, rule := range parseRules(tag) {
-			field.Tag = rule.Name
-			field.Param = rule.Param
+				// Without a task list, e.g. in generated code, the rule runs right away
+				if opts.tasks == nil {
+					_ = v.runCtxTasks(context.Background(), []ctxTask{task}, errs)
+					continue
+				}
 
-			fn, ok := v.get(rule.Name)
-			if !ok {
-				errs.Add(path, fmt.Sprintf("validador [%s] no registrado", rule.Name))
+				*opts.tasks = append(*opts.tasks, task)
 				continue
 			}
 
-			if err := fn(field); err != nil {
-				errs.Add(path, err.Error())
-			}
+			errs.Items = append(errs.Items, FieldError{
+				Field:   field.Path,
+				Message: v.message(field, "", Fail(field, MsgUnknownRule), redact),
+				Rule:    rule.Name,
+				Param:   rule.Param,
+				Code:    CodeUnknownRule,
+			})
+			continue
+		}
+
+		if err := rule.fn(field); err != nil {
+			errs.Items = append(errs.Items, newFieldError(field, v.message(field, rule.Message, err, redact), redact))
 		}
 	}
 }
 
 func shouldIgnore(fieldName string, path string, opts options) bool {
+	path = stripIndexes(path)
+
 	switch opts.mode {
 	case modeSkip:
 		return inSet(opts.fields, fieldName) || inSet(opts.fields, path)
@@ -193,7 +330,7 @@ func shouldDiveForSelectedPath(path string, opts options) bool {
 		return false
 	}
 
-	prefix := path + "."
+	prefix := stripIndexes(path) + "."
 
 	for selected := range opts.fields {
 		if strings.HasPrefix(selected, prefix) {
@@ -204,6 +341,30 @@ func shouldDiveForSelectedPath(path string, opts options) bool {
 	return false
 }
 
+// stripIndexes removes the element indexes from path, so "Items[2].Price"
+// matches the skipped or selected path "Items.Price".
+func stripIndexes(path string) string {
+	if !strings.Contains(path, "[") {
+		return path
+	}
+
+	var b strings.Builder
+	depth := 0
+
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
 func toSet(values []string) map[string]struct{} {
 	set := make(map[string]struct{}, len(values))
 
@@ -245,6 +406,23 @@ func shouldDive(v reflect.Value) bool {
 	return !isTime
 }
 
+// hasStructElements reports whether the elements of a slice, array or map are
+// structs that must be validated even without dive.
+func hasStructElements(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return false
+	}
+
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	return elem.Kind() == reflect.Struct && elem != reflect.TypeOf(time.Time{})
+}
+
 func indirectValue(v reflect.Value) reflect.Value {
 	for v.Kind() == reflect.Pointer && !v.IsNil() {
 		v = v.Elem()
@@ -285,19 +463,36 @@ func buildField(path string, name string, v reflect.Value) Field {
 	return field
 }
 
+// CheckRule is a rule of a chk tag with the template of its chkmsg tag
+type CheckRule struct {
+	Name    string
+	Param   string
+	Message string
+}
+
+func (r CheckRule) ruleName() string {
+	return r.Name
+}
+
+// rule is a CheckRule bound to its validator
 type rule struct {
-	Name  string
-	Param string
+	CheckRule
+
+	fn    ValidatorFunc
+	ctxFn ValidatorCtxFunc
 }
 
-func parseRules(tag string) []rule {
+// ParseTag reads the rules of a chk tag and attaches the templates of its
+// chkmsg tag, see parseMessages. kcheckgen uses it to read the tags the same
+// way as the runtime validation.
+func ParseTag(tag string, messageTag string) []CheckRule {
 	tag = strings.TrimSpace(tag)
 	if tag == "" {
 		return nil
 	}
 
 	parts := strings.Fields(tag)
-	rules := make([]rule, 0, len(parts))
+	rules := make([]CheckRule, 0, len(parts))
 
 	for _, part := range parts {
 		name, param, hasParam := strings.Cut(part, "=")
@@ -307,7 +502,7 @@ func parseRules(tag string) []rule {
 			continue
 		}
 
-		r := rule{Name: name}
+		r := CheckRule{Name: name}
 
 		if hasParam {
 			r.Param = strings.TrimSpace(param)
@@ -316,15 +511,72 @@ func parseRules(tag string) []rule {
 		rules = append(rules, r)
 	}
 
+	messages := parseMessages(messageTag)
+	if messages == nil {
+		return rules
+	}
+
+	for i := range rules {
+		if message, ok := messages[rules[i].Name]; ok {
+			rules[i].Message = message
+		} else {
+			rules[i].Message = messages[""]
+		}
+	}
+
 	return rules
 }
 
+// SplitDive separates the rules of the container from the rules written after
+// dive, which apply to each element.
+func SplitDive(rules []CheckRule) (fieldRules []CheckRule, elemRules []CheckRule, dive bool) {
+	return splitDive(rules)
+}
+
+func splitDive[R interface{ ruleName() string }](rules []R) (fieldRules []R, elemRules []R, dive bool) {
+	for i, r := range rules {
+		if r.ruleName() == "dive" {
+			return rules[:i], rules[i+1:], true
+		}
+	}
+
+	return rules, nil, false
+}
+
 var defaultValidator = New()
 
 func Register(name string, fn ValidatorFunc) {
 	defaultValidator.Register(name, fn)
 }
 
+func RegisterStruct(sample any, fn StructFunc) {
+	defaultValidator.RegisterStruct(sample, fn)
+}
+
+func RegisterCtx(name string, fn ValidatorCtxFunc) {
+	defaultValidator.RegisterCtx(name, fn)
+}
+
+func SetNameFunc(fn NameFunc) {
+	defaultValidator.SetNameFunc(fn)
+}
+
+func UseTagName(tag string) {
+	defaultValidator.UseTagName(tag)
+}
+
+func ExposeValues(expose bool) {
+	defaultValidator.ExposeValues(expose)
+}
+
+func SetLocale(locale string) {
+	defaultValidator.SetLocale(locale)
+}
+
+func SetMessage(key string, template string) {
+	defaultValidator.SetMessage(key, template)
+}
+
 func Struct(input any) error {
 	return defaultValidator.Struct(input)
 }
@@ -336,3 +588,23 @@ func Valid(i any, skips ...string) error {
 func ValidSelect(i any, selected ...string) error {
 	return defaultValidator.StructSelect(i, selected...)
 }
+
+func Var(value any, rules string) error {
+	return defaultValidator.Var(value, rules)
+}
+
+func VarWithName(name string, value any, rules string) error {
+	return defaultValidator.VarWithName(name, value, rules)
+}
+
+func StructCtx(ctx context.Context, input any) error {
+	return defaultValidator.StructCtx(ctx, input)
+}
+
+func ValidCtx(ctx context.Context, i any, skips ...string) error {
+	return defaultValidator.StructSkipCtx(ctx, i, skips...)
+}
+
+func ValidSelectCtx(ctx context.Context, i any, selected ...string) error {
+	return defaultValidator.StructSelectCtx(ctx, i, selected...)
+}
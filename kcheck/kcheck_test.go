@@ -1,6 +1,7 @@
 package kcheck
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -451,3 +452,101 @@ func TestTagDashIgnored(t *testing.T) {
 		t.Fatalf("expected chk dash to ignore field, got: %v", err)
 	}
 }
+
+type testItem struct {
+	Name  string  `chk:"required"`
+	Price float64 `chk:"gt=0"`
+}
+
+type testOrder struct {
+	Items  []testItem        `chk:"min=1"`
+	Refs   []*testItem       `chk:"dive required"`
+	Emails []string          `chk:"max=3 dive required email"`
+	Meta   map[string]string `chk:"dive min=2"`
+	Matrix [][]int           `chk:"dive dive gte=0"`
+}
+
+func errorFields(err error) []string {
+	var fields []string
+	if errs, ok := err.(Errors); ok {
+		for _, item := range errs.Items {
+			fields = append(fields, item.Field)
+		}
+	}
+	return fields
+}
+
+func TestDiveSliceOfStructs(t *testing.T) {
+	order := testOrder{
+		Items:  []testItem{{Name: "a", Price: 1}, {Name: "", Price: 0}},
+		Refs:   []*testItem{{Name: "b", Price: 1}, nil},
+		Emails: []string{"a@b.com", "bad"},
+		Meta:   map[string]string{"ok": "valor", "bad": "x"},
+		Matrix: [][]int{{1, 2}, {3, -1}},
+	}
+
+	got := strings.Join(errorFields(Valid(order)), ",")
+	want := "Items[1].Name,Items[1].Price,Refs[1],Emails[1],Meta[bad],Matrix[1][1]"
+	if got != want {
+		t.Fatalf("fields = %s, want %s", got, want)
+	}
+}
+
+func TestDiveMapIntegerKeys(t *testing.T) {
+	type scores struct {
+		Meta map[int]int `chk:"dive gte=0"`
+	}
+
+	got := strings.Join(errorFields(Valid(scores{Meta: map[int]int{10: -1, 2: -1, 1: -1}})), ",")
+	if got != "Meta[1],Meta[2],Meta[10]" {
+		t.Fatalf("fields = %s, want Meta[1],Meta[2],Meta[10]", got)
+	}
+}
+
+func TestDiveContainerRules(t *testing.T) {
+	order := testOrder{
+		Emails: []string{"a@b.com", "c@d.com", "e@f.com", "g@h.com"},
+	}
+
+	got := strings.Join(errorFields(Valid(order)), ",")
+	if got != "Items,Emails" {
+		t.Fatalf("fields = %s, want Items,Emails", got)
+	}
+}
+
+func TestDiveSkipAndSelect(t *testing.T) {
+	order := testOrder{
+		Items:  []testItem{{Name: "", Price: 0}},
+		Emails: []string{"bad"},
+	}
+
+	got := strings.Join(errorFields(Valid(order, "Items.Price", "Emails")), ",")
+	if got != "Items[0].Name" {
+		t.Fatalf("skip fields = %s, want Items[0].Name", got)
+	}
+
+	got = strings.Join(errorFields(ValidSelect(order, "Items.Price")), ",")
+	if got != "Items[0].Price" {
+		t.Fatalf("select fields = %s, want Items[0].Price", got)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	rules := ParseTag("required min=3 dive email", "required=Ingrese {field}; Valor inválido")
+
+	want := []CheckRule{
+		{Name: "required", Message: "Ingrese {field}"},
+		{Name: "min", Param: "3", Message: "Valor inválido"},
+		{Name: "dive", Message: "Valor inválido"},
+		{Name: "email", Message: "Valor inválido"},
+	}
+
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("ParseTag() = %+v, want %+v", rules, want)
+	}
+
+	fieldRules, elemRules, dive := SplitDive(rules)
+	if !dive || !reflect.DeepEqual(fieldRules, want[:2]) || !reflect.DeepEqual(elemRules, want[3:]) {
+		t.Fatalf("SplitDive() = %+v, %+v, %v", fieldRules, elemRules, dive)
+	}
+}
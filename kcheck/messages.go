@@ -0,0 +1,258 @@
+package kcheck
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of a new Validator and the fallback of every other locale
+const DefaultLocale = "es"
+
+// Message keys of the built-in rules. Templates use the placeholders {field},
+// {param}, {value} and {rule}, plus {other} and {values} in cross-field rules.
+const (
+	MsgRequired       = "required"
+	MsgLenString      = "len.string"
+	MsgLenItems       = "len.items"
+	MsgMinString      = "min.string"
+	MsgMinItems       = "min.items"
+	MsgMinNumber      = "min.number"
+	MsgMaxString      = "max.string"
+	MsgMaxItems       = "max.items"
+	MsgMaxNumber      = "max.number"
+	MsgEmail          = "email"
+	MsgUUID           = "uuid"
+	MsgURL            = "url"
+	MsgIP             = "ip"
+	MsgIPv4           = "ipv4"
+	MsgIPv6           = "ipv6"
+	MsgAlpha          = "alpha"
+	MsgAlphanum       = "alphanum"
+	MsgNum            = "num"
+	MsgDecimal        = "decimal"
+	MsgLower          = "lower"
+	MsgUpper          = "upper"
+	MsgOneOf          = "oneof"
+	MsgPrefix         = "prefix"
+	MsgSuffix         = "suffix"
+	MsgContains       = "contains"
+	MsgDate           = "date"
+	MsgTime           = "time"
+	MsgDateTime       = "datetime"
+	MsgUTCFormat      = "utc.format"
+	MsgUTC            = "utc"
+	MsgGt             = "gt"
+	MsgGte            = "gte"
+	MsgLt             = "lt"
+	MsgLte            = "lte"
+	MsgEqField        = "eqfield"
+	MsgNeField        = "nefield"
+	MsgGtField        = "gtfield"
+	MsgGteField       = "gtefield"
+	MsgLtField        = "ltfield"
+	MsgLteField       = "ltefield"
+	MsgRequiredIf     = "required_if"
+	MsgRequiredUnless = "required_unless"
+	MsgRequiredWith   = "required_with"
+	MsgExcludedWith   = "excluded_with"
+
+	MsgInvalidParam            = "invalid_param"
+	MsgInvalidLength           = "invalid_param.length"
+	MsgInvalidOneOf            = "invalid_param.oneof"
+	MsgUnsupported             = "unsupported"
+	MsgUnsupportedString       = "unsupported.string"
+	MsgUnsupportedStringNumber = "unsupported.string_number"
+	MsgUnsupportedStringTime   = "unsupported.string_time"
+	MsgUnsupportedNumber       = "unsupported.number"
+	MsgFieldNotFound           = "field_not_found"
+	MsgUnknownRule             = "unknown_rule"
+)
+
+// MessageError is returned by validators whose message comes from a template.
+// The Validator renders it in its locale; Error renders it in DefaultLocale.
+type MessageError struct {
+	Key   string
+	Field Field
+	Args  map[string]string
+}
+
+// Fail returns a MessageError for key. Custom validators use it together with
+// SetMessage or RegisterLocale to get translatable messages.
+func Fail(f Field, key string) error {
+	return &MessageError{Key: key, Field: f}
+}
+
+func failWith(f Field, key string, args map[string]string) error {
+	return &MessageError{Key: key, Field: f, Args: args}
+}
+
+func (e *MessageError) Error() string {
+	return render(lookupTemplate(DefaultLocale, e.Key, nil), e.Field, e.Args, false)
+}
+
+var (
+	localesMutex sync.RWMutex
+	locales      = map[string]map[string]string{
+		"es": messagesES,
+		"en": messagesEN,
+		"pt": messagesPT,
+	}
+)
+
+// RegisterLocale adds a locale or replaces some of the templates of an existing one
+func RegisterLocale(locale string, messages map[string]string) {
+	localesMutex.Lock()
+	defer localesMutex.Unlock()
+
+	bundle := make(map[string]string, len(locales[locale])+len(messages))
+	for key, template := range locales[locale] {
+		bundle[key] = template
+	}
+
+	for key, template := range messages {
+		bundle[key] = template
+	}
+
+	locales[locale] = bundle
+}
+
+// lookupTemplate resolves key in overrides, then in locale and then in DefaultLocale
+func lookupTemplate(locale string, key string, overrides map[string]string) string {
+	if template, ok := overrides[key]; ok {
+		return template
+	}
+
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+
+	if template, ok := locales[locale][key]; ok {
+		return template
+	}
+
+	if template, ok := locales[DefaultLocale][key]; ok {
+		return template
+	}
+
+	return key
+}
+
+// render fills the placeholders of template. With redact {value} is replaced
+// by RedactedValue, like FieldError.Value.
+func render(template string, f Field, args map[string]string, redact bool) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+
+	value := ""
+	switch {
+	case f.IsNil || f.Value == nil:
+	case redact:
+		value = RedactedValue
+	default:
+		value = fmt.Sprint(f.Value)
+	}
+
+	replacements := []string{
+		"{field}", f.Path,
+		"{param}", f.Param,
+		"{value}", value,
+		"{rule}", f.Tag,
+	}
+
+	for name, arg := range args {
+		replacements = append(replacements, "{"+name+"}", arg)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// SetLocale selects the bundle used for the messages, e.g. "en"
+func (v *Validator) SetLocale(locale string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.locale = locale
+}
+
+// SetMessage overrides the template of key for this Validator only
+func (v *Validator) SetMessage(key string, template string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.messages == nil {
+		v.messages = make(map[string]string)
+	}
+
+	v.messages[key] = template
+}
+
+// message returns the text of a failed rule. custom is the chkmsg template of
+// the field, which replaces the message of any rule, including custom ones.
+func (v *Validator) message(f Field, custom string, err error, redact bool) string {
+	var msgErr *MessageError
+	isTemplate := errors.As(err, &msgErr)
+
+	if custom != "" {
+		var args map[string]string
+		if isTemplate {
+			args = msgErr.Args
+		}
+
+		return render(custom, f, args, redact)
+	}
+
+	if !isTemplate {
+		return err.Error()
+	}
+
+	v.mu.RLock()
+	locale, overrides := v.locale, v.messages
+	v.mu.RUnlock()
+
+	return render(lookupTemplate(locale, msgErr.Key, overrides), msgErr.Field, msgErr.Args, redact)
+}
+
+// parseMessages reads the chkmsg tag: a single template for every rule, or
+// "rule=template" pairs separated by ";". A part without a rule applies to the
+// rules not listed.
+func parseMessages(tag string) map[string]string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil
+	}
+
+	messages := make(map[string]string)
+
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, template, ok := strings.Cut(part, "=")
+		if ok && isRuleName(name) {
+			messages[name] = strings.TrimSpace(template)
+			continue
+		}
+
+		messages[""] = part
+	}
+
+	return messages
+}
+
+func isRuleName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
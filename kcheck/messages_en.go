@@ -0,0 +1,59 @@
+package kcheck
+
+var messagesEN = map[string]string{
+	MsgRequired:       "the field [{field}] is required",
+	MsgLenString:      "the field [{field}] must have length [{param}]",
+	MsgLenItems:       "the field [{field}] must have [{param}] items",
+	MsgMinString:      "the field [{field}] must have at least [{param}] characters",
+	MsgMinItems:       "the field [{field}] must have at least [{param}] items",
+	MsgMinNumber:      "the field [{field}] must be greater than or equal to [{param}]",
+	MsgMaxString:      "the field [{field}] must have at most [{param}] characters",
+	MsgMaxItems:       "the field [{field}] must have at most [{param}] items",
+	MsgMaxNumber:      "the field [{field}] must be less than or equal to [{param}]",
+	MsgEmail:          "the field [{field}] is not a valid email",
+	MsgUUID:           "the field [{field}] must be a valid UUID v4",
+	MsgURL:            "the field [{field}] must be a valid URL",
+	MsgIP:             "the field [{field}] must be a valid IP",
+	MsgIPv4:           "the field [{field}] must be a valid IPv4",
+	MsgIPv6:           "the field [{field}] must be a valid IPv6",
+	MsgAlpha:          "the field [{field}] must contain only letters",
+	MsgAlphanum:       "the field [{field}] must contain only letters and numbers",
+	MsgNum:            "the field [{field}] must contain only numbers",
+	MsgDecimal:        "the field [{field}] must be a decimal",
+	MsgLower:          "the field [{field}] must be lowercase",
+	MsgUpper:          "the field [{field}] must be uppercase",
+	MsgOneOf:          "the field [{field}] must be one of: [{param}]",
+	MsgPrefix:         "the field [{field}] must start with [{param}]",
+	MsgSuffix:         "the field [{field}] must end with [{param}]",
+	MsgContains:       "the field [{field}] must contain [{param}]",
+	MsgDate:           "the field [{field}] must be a valid date",
+	MsgTime:           "the field [{field}] must be a valid time",
+	MsgDateTime:       "the field [{field}] must be a valid date and time",
+	MsgUTCFormat:      "the field [{field}] must use the UTC RFC3339 format",
+	MsgUTC:            "the field [{field}] must be in UTC",
+	MsgGt:             "the field [{field}] must be greater than [{param}]",
+	MsgGte:            "the field [{field}] must be greater than or equal to [{param}]",
+	MsgLt:             "the field [{field}] must be less than [{param}]",
+	MsgLte:            "the field [{field}] must be less than or equal to [{param}]",
+	MsgEqField:        "the field [{field}] must be equal to [{param}]",
+	MsgNeField:        "the field [{field}] must be different from [{param}]",
+	MsgGtField:        "the field [{field}] must be greater than [{param}]",
+	MsgGteField:       "the field [{field}] must be greater than or equal to [{param}]",
+	MsgLtField:        "the field [{field}] must be less than [{param}]",
+	MsgLteField:       "the field [{field}] must be less than or equal to [{param}]",
+	MsgRequiredIf:     "the field [{field}] is required when [{other} is {values}]",
+	MsgRequiredUnless: "the field [{field}] is required unless [{other} is {values}]",
+	MsgRequiredWith:   "the field [{field}] is required together with [{other}]",
+	MsgExcludedWith:   "the field [{field}] must not be sent together with [{other}]",
+
+	MsgInvalidParam:            "invalid parameter for [{rule}] in [{field}]",
+	MsgInvalidLength:           "invalid parameter for [{rule}] in [{field}]",
+	MsgInvalidOneOf:            "[{rule}] requires values in [{field}]",
+	MsgUnsupported:             "[{rule}] does not support the type of the field [{field}]",
+	MsgUnsupportedString:       "[{rule}] only supports strings in [{field}]",
+	MsgUnsupportedStringNumber: "[{rule}] only supports strings or numbers in [{field}]",
+	MsgUnsupportedStringTime:   "[{rule}] only supports strings or time.Time in [{field}]",
+	MsgUnsupportedNumber:       "[{rule}] only supports numbers in [{field}]",
+	MsgFieldNotFound:           "field [{other}] not found for [{rule}] in [{field}]",
+	MsgUnknownRule:             "validator [{rule}] not registered",
+}
@@ -0,0 +1,59 @@
+package kcheck
+
+var messagesES = map[string]string{
+	MsgRequired:       "el campo [{field}] es requerido",
+	MsgLenString:      "el campo [{field}] debe tener longitud [{param}]",
+	MsgLenItems:       "el campo [{field}] debe tener [{param}] elementos",
+	MsgMinString:      "el campo [{field}] debe tener mínimo [{param}] caracteres",
+	MsgMinItems:       "el campo [{field}] debe tener mínimo [{param}] elementos",
+	MsgMinNumber:      "el campo [{field}] debe ser mayor o igual a [{param}]",
+	MsgMaxString:      "el campo [{field}] debe tener máximo [{param}] caracteres",
+	MsgMaxItems:       "el campo [{field}] debe tener máximo [{param}] elementos",
+	MsgMaxNumber:      "el campo [{field}] debe ser menor o igual a [{param}]",
+	MsgEmail:          "el campo [{field}] no es un correo válido",
+	MsgUUID:           "el campo [{field}] debe ser un UUID v4 válido",
+	MsgURL:            "el campo [{field}] debe ser una URL válida",
+	MsgIP:             "el campo [{field}] debe ser una IP válida",
+	MsgIPv4:           "el campo [{field}] debe ser una IPv4 válida",
+	MsgIPv6:           "el campo [{field}] debe ser una IPv6 válida",
+	MsgAlpha:          "el campo [{field}] solo debe contener letras",
+	MsgAlphanum:       "el campo [{field}] solo debe contener letras y números",
+	MsgNum:            "el campo [{field}] solo debe contener números",
+	MsgDecimal:        "el campo [{field}] debe ser decimal",
+	MsgLower:          "el campo [{field}] debe estar en minúsculas",
+	MsgUpper:          "el campo [{field}] debe estar en mayúsculas",
+	MsgOneOf:          "el campo [{field}] debe ser uno de: [{param}]",
+	MsgPrefix:         "el campo [{field}] debe empezar con [{param}]",
+	MsgSuffix:         "el campo [{field}] debe terminar con [{param}]",
+	MsgContains:       "el campo [{field}] debe contener [{param}]",
+	MsgDate:           "el campo [{field}] debe ser una fecha válida",
+	MsgTime:           "el campo [{field}] debe ser una hora válida",
+	MsgDateTime:       "el campo [{field}] debe ser fecha y hora válida",
+	MsgUTCFormat:      "el campo [{field}] debe tener formato UTC RFC3339",
+	MsgUTC:            "el campo [{field}] debe estar en UTC",
+	MsgGt:             "el campo [{field}] debe ser mayor que [{param}]",
+	MsgGte:            "el campo [{field}] debe ser mayor o igual que [{param}]",
+	MsgLt:             "el campo [{field}] debe ser menor que [{param}]",
+	MsgLte:            "el campo [{field}] debe ser menor o igual que [{param}]",
+	MsgEqField:        "el campo [{field}] debe ser igual a [{param}]",
+	MsgNeField:        "el campo [{field}] debe ser distinto de [{param}]",
+	MsgGtField:        "el campo [{field}] debe ser mayor que [{param}]",
+	MsgGteField:       "el campo [{field}] debe ser mayor o igual que [{param}]",
+	MsgLtField:        "el campo [{field}] debe ser menor que [{param}]",
+	MsgLteField:       "el campo [{field}] debe ser menor o igual que [{param}]",
+	MsgRequiredIf:     "el campo [{field}] es requerido cuando [{other} es {values}]",
+	MsgRequiredUnless: "el campo [{field}] es requerido salvo que [{other} sea {values}]",
+	MsgRequiredWith:   "el campo [{field}] es requerido junto con [{other}]",
+	MsgExcludedWith:   "el campo [{field}] no debe enviarse junto con [{other}]",
+
+	MsgInvalidParam:            "parámetro inválido para [{rule}] en [{field}]",
+	MsgInvalidLength:           "parámetro inválido para {rule} en [{field}]",
+	MsgInvalidOneOf:            "oneof requiere valores en [{field}]",
+	MsgUnsupported:             "[{rule}] no soporta el tipo del campo [{field}]",
+	MsgUnsupportedString:       "{rule} solo soporta string en [{field}]",
+	MsgUnsupportedStringNumber: "{rule} solo soporta string o número en [{field}]",
+	MsgUnsupportedStringTime:   "{rule} solo soporta string o time.Time en [{field}]",
+	MsgUnsupportedNumber:       "[{rule}] solo soporta números",
+	MsgFieldNotFound:           "campo [{other}] no encontrado para [{rule}] en [{field}]",
+	MsgUnknownRule:             "validador [{rule}] no registrado",
+}
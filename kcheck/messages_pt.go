@@ -0,0 +1,59 @@
+package kcheck
+
+var messagesPT = map[string]string{
+	MsgRequired:       "o campo [{field}] é obrigatório",
+	MsgLenString:      "o campo [{field}] deve ter comprimento [{param}]",
+	MsgLenItems:       "o campo [{field}] deve ter [{param}] elementos",
+	MsgMinString:      "o campo [{field}] deve ter no mínimo [{param}] caracteres",
+	MsgMinItems:       "o campo [{field}] deve ter no mínimo [{param}] elementos",
+	MsgMinNumber:      "o campo [{field}] deve ser maior ou igual a [{param}]",
+	MsgMaxString:      "o campo [{field}] deve ter no máximo [{param}] caracteres",
+	MsgMaxItems:       "o campo [{field}] deve ter no máximo [{param}] elementos",
+	MsgMaxNumber:      "o campo [{field}] deve ser menor ou igual a [{param}]",
+	MsgEmail:          "o campo [{field}] não é um e-mail válido",
+	MsgUUID:           "o campo [{field}] deve ser um UUID v4 válido",
+	MsgURL:            "o campo [{field}] deve ser uma URL válida",
+	MsgIP:             "o campo [{field}] deve ser um IP válido",
+	MsgIPv4:           "o campo [{field}] deve ser um IPv4 válido",
+	MsgIPv6:           "o campo [{field}] deve ser um IPv6 válido",
+	MsgAlpha:          "o campo [{field}] deve conter apenas letras",
+	MsgAlphanum:       "o campo [{field}] deve conter apenas letras e números",
+	MsgNum:            "o campo [{field}] deve conter apenas números",
+	MsgDecimal:        "o campo [{field}] deve ser decimal",
+	MsgLower:          "o campo [{field}] deve estar em minúsculas",
+	MsgUpper:          "o campo [{field}] deve estar em maiúsculas",
+	MsgOneOf:          "o campo [{field}] deve ser um de: [{param}]",
+	MsgPrefix:         "o campo [{field}] deve começar com [{param}]",
+	MsgSuffix:         "o campo [{field}] deve terminar com [{param}]",
+	MsgContains:       "o campo [{field}] deve conter [{param}]",
+	MsgDate:           "o campo [{field}] deve ser uma data válida",
+	MsgTime:           "o campo [{field}] deve ser uma hora válida",
+	MsgDateTime:       "o campo [{field}] deve ser uma data e hora válida",
+	MsgUTCFormat:      "o campo [{field}] deve ter o formato UTC RFC3339",
+	MsgUTC:            "o campo [{field}] deve estar em UTC",
+	MsgGt:             "o campo [{field}] deve ser maior que [{param}]",
+	MsgGte:            "o campo [{field}] deve ser maior ou igual a [{param}]",
+	MsgLt:             "o campo [{field}] deve ser menor que [{param}]",
+	MsgLte:            "o campo [{field}] deve ser menor ou igual a [{param}]",
+	MsgEqField:        "o campo [{field}] deve ser igual a [{param}]",
+	MsgNeField:        "o campo [{field}] deve ser diferente de [{param}]",
+	MsgGtField:        "o campo [{field}] deve ser maior que [{param}]",
+	MsgGteField:       "o campo [{field}] deve ser maior ou igual a [{param}]",
+	MsgLtField:        "o campo [{field}] deve ser menor que [{param}]",
+	MsgLteField:       "o campo [{field}] deve ser menor ou igual a [{param}]",
+	MsgRequiredIf:     "o campo [{field}] é obrigatório quando [{other} é {values}]",
+	MsgRequiredUnless: "o campo [{field}] é obrigatório a menos que [{other} seja {values}]",
+	MsgRequiredWith:   "o campo [{field}] é obrigatório junto com [{other}]",
+	MsgExcludedWith:   "o campo [{field}] não deve ser enviado junto com [{other}]",
+
+	MsgInvalidParam:            "parâmetro inválido para [{rule}] em [{field}]",
+	MsgInvalidLength:           "parâmetro inválido para [{rule}] em [{field}]",
+	MsgInvalidOneOf:            "[{rule}] requer valores em [{field}]",
+	MsgUnsupported:             "[{rule}] não suporta o tipo do campo [{field}]",
+	MsgUnsupportedString:       "[{rule}] só suporta string em [{field}]",
+	MsgUnsupportedStringNumber: "[{rule}] só suporta string ou número em [{field}]",
+	MsgUnsupportedStringTime:   "[{rule}] só suporta string ou time.Time em [{field}]",
+	MsgUnsupportedNumber:       "[{rule}] só suporta números em [{field}]",
+	MsgFieldNotFound:           "campo [{other}] não encontrado para [{rule}] em [{field}]",
+	MsgUnknownRule:             "validador [{rule}] não registrado",
+}
@@ -0,0 +1,253 @@
+package kcheck
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+func messages(err error) []string {
+	var result []string
+	if errs, ok := err.(Errors); ok {
+		for _, item := range errs.Items {
+			result = append(result, item.Message)
+		}
+	}
+	return result
+}
+
+func TestDefaultMessages(t *testing.T) {
+	type dto struct {
+		Name  string `chk:"required"`
+		Age   int    `chk:"gte=18"`
+		Email string `chk:"email"`
+	}
+
+	got := messages(Valid(dto{Age: 10, Email: "x"}))
+	want := []string{
+		"el campo [Name] es requerido",
+		"el campo [Age] debe ser mayor o igual que [18]",
+		"el campo [Email] no es un correo válido",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("messages = %v", got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("messages[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	type dto struct {
+		Name string `chk:"required"`
+		Type string
+		RUC  string `chk:"required_if=Type:company"`
+	}
+
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"en", []string{"the field [Name] is required", "the field [RUC] is required when [Type is company]"}},
+		{"pt", []string{"o campo [Name] é obrigatório", "o campo [RUC] é obrigatório quando [Type é company]"}},
+		{"fr", []string{"el campo [Name] es requerido", "el campo [RUC] es requerido cuando [Type es company]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			v := New()
+			v.SetLocale(tt.locale)
+
+			got := messages(v.Struct(dto{Type: "company"}))
+			if len(got) != 2 || got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Fatalf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMessageAndCustomValidator(t *testing.T) {
+	type dto struct {
+		Code string `chk:"required startsx"`
+	}
+
+	v := New()
+	v.ExposeValues(true)
+	v.SetMessage(MsgRequired, "falta {field}")
+	v.SetMessage("startsx", "{field} debe empezar con x, se recibió {value}")
+	v.Register("startsx", func(f Field) error {
+		if s, _ := f.Value.(string); len(s) > 0 && s[0] != 'x' {
+			return Fail(f, "startsx")
+		}
+		return nil
+	})
+
+	if got := messages(v.Struct(dto{})); len(got) != 1 || got[0] != "falta Code" {
+		t.Fatalf("messages = %q", got)
+	}
+
+	if got := messages(v.Struct(dto{Code: "abc"})); len(got) != 1 || got[0] != "Code debe empezar con x, se recibió abc" {
+		t.Fatalf("messages = %q", got)
+	}
+
+	if got := messages(New().Struct(dto{})); got[0] != "el campo [Code] es requerido" {
+		t.Fatalf("SetMessage must not change other validators, got %q", got)
+	}
+}
+
+func TestChkmsgTag(t *testing.T) {
+	type dto struct {
+		Email  string   `chk:"required email" chkmsg:"required=Ingrese su correo; email=El correo {value} no es válido"`
+		Name   string   `chk:"required min=3" chkmsg:"Nombre inválido"`
+		Tags   []string `chk:"min=1 dive required" chkmsg:"min=Agregue una etiqueta; Etiqueta vacía"`
+		Unique string   `chk:"unique" chkmsg:"El código {value} ya existe"`
+	}
+
+	v := New()
+	v.ExposeValues(true)
+	v.RegisterCtx("unique", func(ctx context.Context, f Field) error {
+		return Fail(f, "unique")
+	})
+
+	got := messages(v.Struct(dto{Email: "x", Name: "ab", Tags: []string{""}, Unique: "A1"}))
+	want := []string{
+		"El correo x no es válido",
+		"Nombre inválido",
+		"Etiqueta vacía",
+		"El código A1 ya existe",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("messages = %q", got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("messages[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	got = messages(v.StructSelect(dto{}, "Email", "Tags"))
+	if len(got) != 3 || got[0] != "Ingrese su correo" || got[2] != "Agregue una etiqueta" {
+		t.Fatalf("messages = %q", got)
+	}
+}
+
+func TestSpanishMessagesKeepTheirText(t *testing.T) {
+	tests := []struct {
+		rule  string
+		param string
+		value any
+		want  string
+	}{
+		{"len", "x", "abc", "parámetro inválido para len en [Code]"},
+		{"len", "2", 10, "len solo soporta string en [Code]"},
+		{"min", "x", "abc", "parámetro inválido para min en [Code]"},
+		{"max", "2", true, "max solo soporta string o número en [Code]"},
+		{"email", "", 10, "email solo soporta string en [Code]"},
+		{"date", "", 10, "date solo soporta string o time.Time en [Code]"},
+		{"gt", "1", "abc", "[gt] solo soporta números"},
+		{"gt", "x", 10, "parámetro inválido para [gt] en [Code]"},
+		{"oneof", " ", "a", "oneof requiere valores en [Code]"},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.rule+" "+tt.want, func(t *testing.T) {
+			err := v.VarWithName("Code", tt.value, tt.rule+"="+tt.param)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := err.(Errors).Items[0].Message; got != tt.want {
+				t.Errorf("message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageSetLocale(t *testing.T) {
+	t.Cleanup(func() {
+		SetLocale(DefaultLocale)
+		SetMessage(MsgRequired, messagesES[MsgRequired])
+	})
+
+	type dto struct {
+		Name string `chk:"required"`
+		Age  int    `chk:"gte=18"`
+	}
+
+	SetLocale("en")
+	SetMessage(MsgRequired, "falta {field}")
+
+	got := messages(Valid(dto{Age: 10}))
+	if len(got) != 2 || got[0] != "falta Name" || got[1] != "the field [Age] must be greater than or equal to [18]" {
+		t.Fatalf("messages = %q", got)
+	}
+}
+
+// restoreLocales restores the registered locales when the test ends
+func restoreLocales(t *testing.T) {
+	t.Helper()
+
+	localesMutex.RLock()
+	saved := maps.Clone(locales)
+	localesMutex.RUnlock()
+
+	t.Cleanup(func() {
+		localesMutex.Lock()
+		locales = saved
+		localesMutex.Unlock()
+	})
+}
+
+func TestRegisterLocale(t *testing.T) {
+	restoreLocales(t)
+
+	RegisterLocale("qu", map[string]string{MsgRequired: "[{field}] munakunmi"})
+
+	v := New()
+	v.SetLocale("qu")
+
+	type dto struct {
+		Name string `chk:"required email"`
+	}
+
+	got := messages(v.Struct(dto{}))
+	if len(got) != 2 || got[0] != "[Name] munakunmi" || got[1] != "el campo [Name] no es un correo válido" {
+		t.Fatalf("messages = %q", got)
+	}
+}
+
+func TestMessageRedactedValue(t *testing.T) {
+	type signup struct {
+		Password string `chk:"min=8 redact" chkmsg:"min=bad {value}"`
+		Email    string `chk:"email"`
+	}
+
+	v := New()
+	v.ExposeValues(true)
+	v.SetMessage(MsgEmail, "el correo {value} no es válido")
+
+	err := v.Struct(signup{Password: "secret1", Email: "kevin@"})
+	items := err.(Errors).Items
+
+	if items[0].Message != "bad "+RedactedValue || items[0].Value != RedactedValue {
+		t.Fatalf("expected redacted password, got %+v", items[0])
+	}
+
+	if items[1].Message != "el correo kevin@ no es válido" {
+		t.Fatalf("expected the email value, got %q", items[1].Message)
+	}
+
+	v.ExposeValues(false)
+
+	err = v.Struct(signup{Password: "secret123", Email: "kevin@"})
+	if got := err.(Errors).Items[0].Message; got != "el correo "+RedactedValue+" no es válido" {
+		t.Fatalf("expected redacted email, got %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package kcheck
+
+import (
+	"reflect"
+	"strings"
+)
+
+// NameFunc returns the name of a field used in error paths and in the skip and
+// select lists. An empty result falls back to the Go field name.
+type NameFunc func(sf reflect.StructField) string
+
+// TagName returns a NameFunc that reads the name from a tag such as json, form or query.
+// Options after the comma are ignored and "-" falls back to the Go field name.
+func TagName(tag string) NameFunc {
+	return func(sf reflect.StructField) string {
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	}
+}
+
+// SetNameFunc changes how field names are reported, nil restores the Go field names.
+// Cross-field rules keep referring to the Go field names.
+func (v *Validator) SetNameFunc(fn NameFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.nameFunc = fn
+	v.resetPlans()
+}
+
+// UseTagName reports field names from tag, e.g. v.UseTagName("json")
+func (v *Validator) UseTagName(tag string) {
+	v.SetNameFunc(TagName(tag))
+}
+
+// ExposeValues allows the values of the fields to reach FieldError.Value and
+// the {value} placeholder. Values are redacted by default because the errors
+// are sent to clients and logs; fields with the redact rule stay redacted,
+// e.g. chk:"required min=8 redact".
+func (v *Validator) ExposeValues(expose bool) {
+	v.expose.Store(expose)
+}
+
+// redactValues reports whether the values of the fields are hidden
+func (v *Validator) redactValues() bool {
+	return !v.expose.Load()
+}
+
+func displayName(sf reflect.StructField, fn NameFunc) string {
+	if fn == nil {
+		return sf.Name
+	}
+
+	if name := fn(sf); name != "" {
+		return name
+	}
+
+	return sf.Name
+}
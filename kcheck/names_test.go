@@ -0,0 +1,63 @@
+package kcheck
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testDireccion struct {
+	Ciudad string `json:"ciudad" chk:"required"`
+}
+
+type testPersona struct {
+	NombrePersona string          `json:"nombre_persona,omitempty" chk:"required"`
+	Documento     string          `json:"-" form:"doc" chk:"required"`
+	Direccion     testDireccion   `json:"direccion"`
+	Contactos     []testDireccion `json:"contactos"`
+}
+
+func TestUseTagName(t *testing.T) {
+	v := New()
+	v.UseTagName("json")
+
+	persona := testPersona{Contactos: []testDireccion{{}}}
+
+	got := strings.Join(errorFields(v.Struct(persona)), ",")
+	want := "nombre_persona,Documento,direccion.ciudad,contactos[0].ciudad"
+	if got != want {
+		t.Fatalf("fields = %s, want %s", got, want)
+	}
+
+	got = strings.Join(errorFields(v.StructSkip(persona, "nombre_persona", "contactos.ciudad")), ",")
+	if got != "Documento,direccion.ciudad" {
+		t.Fatalf("skip fields = %s", got)
+	}
+
+	got = strings.Join(errorFields(v.StructSelect(persona, "direccion.ciudad")), ",")
+	if got != "direccion.ciudad" {
+		t.Fatalf("select fields = %s", got)
+	}
+}
+
+func TestSetNameFunc(t *testing.T) {
+	v := New()
+	v.UseTagName("form")
+
+	got := strings.Join(errorFields(v.Struct(testPersona{NombrePersona: "x", Direccion: testDireccion{Ciudad: "Lima"}})), ",")
+	if got != "doc" {
+		t.Fatalf("fields = %s, want doc", got)
+	}
+
+	v.SetNameFunc(func(sf reflect.StructField) string { return strings.ToLower(sf.Name) })
+	got = strings.Join(errorFields(v.Struct(testPersona{Documento: "1", Direccion: testDireccion{Ciudad: "Lima"}})), ",")
+	if got != "nombrepersona" {
+		t.Fatalf("fields = %s, want nombrepersona", got)
+	}
+
+	v.SetNameFunc(nil)
+	got = strings.Join(errorFields(v.Struct(testPersona{Documento: "1", Direccion: testDireccion{Ciudad: "Lima"}})), ",")
+	if got != "NombrePersona" {
+		t.Fatalf("fields = %s, want NombrePersona", got)
+	}
+}
@@ -0,0 +1,151 @@
+package kcheck
+
+import (
+	"reflect"
+	"time"
+)
+
+// structPlan is the validation of a struct type compiled once: the fields to
+// visit with their names and parsed rules bound to their validators.
+// Plans are cached per Validator and dropped when its configuration changes.
+type structPlan struct {
+	fields      []fieldPlan
+	self        selfKind
+	structFuncs []StructFunc
+	generation  uint64
+}
+
+type fieldPlan struct {
+	index      int
+	name       string
+	fieldRules []rule
+	elemRules  []rule
+	dive       bool
+	nested     bool
+}
+
+type selfKind int
+
+const (
+	selfNone selfKind = iota
+	selfValue
+	selfPointer
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	structValidatorType = reflect.TypeOf((*StructValidator)(nil)).Elem()
+)
+
+func (v *Validator) plan(rt reflect.Type) *structPlan {
+	if cached, ok := v.plans.Load(rt); ok {
+		return cached.(*structPlan)
+	}
+
+	plan := v.compile(rt)
+
+	// A plan compiled while the configuration changed is used once but not cached
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if plan.generation == v.generation.Load() {
+		cached, _ := v.plans.LoadOrStore(rt, plan)
+		return cached.(*structPlan)
+	}
+
+	return plan
+}
+
+// resetPlans drops the cached plans. It is called with v.mu held every time a
+// setting used by compile changes.
+func (v *Validator) resetPlans() {
+	v.generation.Add(1)
+	v.plans.Clear()
+	v.vars.Clear()
+}
+
+func (v *Validator) compile(rt reflect.Type) *structPlan {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	plan := &structPlan{structFuncs: v.structFuncs[rt], generation: v.generation.Load()}
+
+	switch {
+	case rt.Implements(structValidatorType):
+		plan.self = selfValue
+	case reflect.PointerTo(rt).Implements(structValidatorType):
+		plan.self = selfPointer
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		tag := sf.Tag.Get(v.tag)
+		if tag == "-" {
+			continue
+		}
+
+		rules := v.resolveRules(ParseTag(tag, sf.Tag.Get(MessageTagName)))
+		fieldRules, elemRules, dive := splitDive(rules)
+
+		fp := fieldPlan{
+			index:      i,
+			name:       displayName(sf, v.nameFunc),
+			fieldRules: fieldRules,
+			elemRules:  elemRules,
+			dive:       dive,
+			nested:     isNestedType(sf.Type),
+		}
+
+		if len(fp.fieldRules) == 0 && !fp.dive && !fp.nested {
+			continue
+		}
+
+		plan.fields = append(plan.fields, fp)
+	}
+
+	return plan
+}
+
+// resolveRules binds every rule to its validator. It must be called with v.mu held.
+func (v *Validator) resolveRules(rules []CheckRule) []rule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	resolved := make([]rule, len(rules))
+	for i, r := range rules {
+		resolved[i] = rule{CheckRule: r, fn: v.funcs[r.Name]}
+		if resolved[i].fn == nil {
+			resolved[i].ctxFn = v.ctxFuncs[r.Name]
+		}
+	}
+
+	return resolved
+}
+
+// isNestedType reports whether values of rt may hold structs that are validated on their own
+func isNestedType(rt reflect.Type) bool {
+	for rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+
+	switch rt.Kind() {
+	case reflect.Struct:
+		return rt != timeType
+
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elem := rt.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+
+		return elem.Kind() == reflect.Struct && elem != timeType
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package kcheck
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestPlanCacheInvalidation(t *testing.T) {
+	type dto struct {
+		Code string `json:"code" chk:"startsx"`
+	}
+
+	v := New()
+
+	if err := v.Struct(dto{Code: "abc"}); err == nil || err.(Errors).Items[0].Code != CodeUnknownRule {
+		t.Fatalf("expected unknown rule error, got %v", err)
+	}
+
+	v.Register("startsx", func(f Field) error {
+		if f.Value != "x" {
+			return errors.New("debe ser x")
+		}
+		return nil
+	})
+
+	err := v.Struct(dto{Code: "abc"})
+	if err == nil || err.(Errors).Items[0].Code != "STARTSX" {
+		t.Fatalf("expected startsx error after Register, got %v", err)
+	}
+
+	v.UseTagName("json")
+	if err := v.Struct(dto{Code: "abc"}); err == nil || err.(Errors).Items[0].Field != "code" {
+		t.Fatalf("expected json name after UseTagName, got %v", err)
+	}
+}
+
+func TestPlanConcurrent(t *testing.T) {
+	v := New()
+	order := newBenchOrder()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				if err := v.Struct(order); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	v.Register("noop", func(Field) error { return nil })
+	wg.Wait()
+}
+
+func TestIsNestedType(t *testing.T) {
+	type inner struct{ A string }
+
+	tests := []struct {
+		value any
+		want  bool
+	}{
+		{inner{}, true},
+		{&inner{}, true},
+		{[]inner{}, true},
+		{map[string]*inner{}, true},
+		{[]string{}, false},
+		{"x", false},
+		{newBenchOrder().CreatedAt, false},
+	}
+
+	for _, tt := range tests {
+		v := reflect.TypeOf(tt.value)
+		if got := isNestedType(v); got != tt.want {
+			t.Errorf("isNestedType(%v) = %v, want %v", v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package kcheck
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StructValidator is implemented by types that check invariants spanning several
+// fields. ValidateStruct runs after the field rules of the struct; a returned
+// Errors keeps its fields, relative to the struct, and any other error is
+// reported on the struct itself. The method has its own name so a Validate
+// method that calls kcheck.Struct does not recurse.
+type StructValidator interface {
+	ValidateStruct() error
+}
+
+// StructFunc validates a whole struct, received as the value registered with RegisterStruct
+type StructFunc func(input any) error
+
+// RegisterStruct registers fn for the type of sample, e.g. v.RegisterStruct(Order{}, fn).
+// It runs after the field rules and after ValidateStruct when the type is a StructValidator.
+func (v *Validator) RegisterStruct(sample any, fn StructFunc) {
+	rt := reflect.TypeOf(sample)
+	for rt != nil && rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+
+	if rt == nil {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.structFuncs == nil {
+		v.structFuncs = make(map[reflect.Type][]StructFunc)
+	}
+
+	v.structFuncs[rt] = append(v.structFuncs[rt], fn)
+	v.hasStructFuncs.Store(true)
+	v.resetPlans()
+}
+
+// validateStructLevel runs the StructValidator method and the registered
+// struct functions of rv and merges their errors under path.
+func (v *Validator) validateStructLevel(rv reflect.Value, plan *structPlan, path string, opts options, errs *Errors) {
+	if (plan.self == selfNone && len(plan.structFuncs) == 0) || !rv.CanInterface() {
+		return
+	}
+
+	switch plan.self {
+	case selfValue:
+		v.mergeStructErr(rv.Interface().(StructValidator).ValidateStruct(), path, opts, errs)
+
+	case selfPointer:
+		ptr := reflect.New(rv.Type())
+		if rv.CanAddr() {
+			ptr = rv.Addr()
+		} else {
+			ptr.Elem().Set(rv)
+		}
+
+		v.mergeStructErr(ptr.Interface().(StructValidator).ValidateStruct(), path, opts, errs)
+	}
+
+	for _, fn := range plan.structFuncs {
+		v.mergeStructErr(fn(rv.Interface()), path, opts, errs)
+	}
+}
+
+func (v *Validator) mergeStructErr(err error, path string, opts options, errs *Errors) {
+	if err == nil {
+		return
+	}
+
+	fieldErrs, ok := err.(Errors)
+	if !ok {
+		if !structErrIgnored(path, opts) {
+			errs.Items = append(errs.Items, FieldError{Field: path, Message: err.Error(), Code: CodeStruct})
+		}
+		return
+	}
+
+	for _, item := range fieldErrs.Items {
+		item.Field = joinPath(path, item.Field)
+		if item.Code == "" {
+			item.Code = CodeStruct
+		}
+
+		if structErrIgnored(item.Field, opts) {
+			continue
+		}
+
+		errs.Items = append(errs.Items, item)
+	}
+}
+
+func structErrIgnored(path string, opts options) bool {
+	if path == "" {
+		return opts.mode == modeSelect
+	}
+
+	name := path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		name = path[i+1:]
+	}
+
+	return shouldIgnore(name, path, opts)
+}
+
+func joinPath(parent string, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "":
+		return parent
+	case strings.HasPrefix(field, "["):
+		return parent + field
+	default:
+		return parent + "." + field
+	}
+}
@@ -0,0 +1,106 @@
+package kcheck
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testLine struct {
+	Amount float64 `chk:"gte=0"`
+}
+
+type testInvoice struct {
+	Lines []testLine
+	Total float64
+}
+
+func (i *testInvoice) ValidateStruct() error {
+	var sum float64
+	for _, line := range i.Lines {
+		sum += line.Amount
+	}
+
+	if sum != i.Total {
+		var errs Errors
+		errs.Add("Total", "el total no coincide con la suma de las líneas")
+		return errs
+	}
+
+	return nil
+}
+
+type testPurchase struct {
+	Invoice testInvoice
+	Note    string
+}
+
+func TestStructValidator(t *testing.T) {
+	invoice := testInvoice{Lines: []testLine{{Amount: 10}, {Amount: -1}}, Total: 5}
+
+	got := strings.Join(errorFields(Valid(invoice)), ",")
+	if got != "Lines[1].Amount,Total" {
+		t.Fatalf("fields = %s, want Lines[1].Amount,Total", got)
+	}
+
+	got = strings.Join(errorFields(Valid(testPurchase{Invoice: invoice})), ",")
+	if got != "Invoice.Lines[1].Amount,Invoice.Total" {
+		t.Fatalf("nested fields = %s", got)
+	}
+
+	if err := Valid(testPurchase{Invoice: invoice}, "Invoice.Total", "Invoice.Lines.Amount"); err != nil {
+		t.Fatalf("expected skipped struct errors, got %v", err)
+	}
+
+	got = strings.Join(errorFields(ValidSelect(testPurchase{Invoice: invoice}, "Invoice.Total")), ",")
+	if got != "Invoice.Total" {
+		t.Fatalf("selected fields = %s, want Invoice.Total", got)
+	}
+}
+
+func TestRegisterStruct(t *testing.T) {
+	v := New()
+	v.RegisterStruct(&testPurchase{}, func(input any) error {
+		purchase := input.(testPurchase)
+		if purchase.Note == "" {
+			return errors.New("la compra requiere una nota")
+		}
+		return nil
+	})
+
+	err := v.Struct(testPurchase{Invoice: testInvoice{Total: 0}})
+	if err == nil {
+		t.Fatal("expected struct level error")
+	}
+
+	fieldErrs := err.(Errors)
+	if len(fieldErrs.Items) != 1 || fieldErrs.Items[0].Field != "" || fieldErrs.Items[0].Message != "la compra requiere una nota" {
+		t.Fatalf("errors = %+v", fieldErrs.Items)
+	}
+
+	if err := v.StructSelect(testPurchase{}, "Note"); err != nil {
+		t.Fatalf("expected root error ignored in select mode, got %v", err)
+	}
+}
+
+type testWrappedRequest struct {
+	Name string `chk:"required"`
+}
+
+// Validate wraps kcheck like many callers do; kcheck must not call it back
+func (r testWrappedRequest) Validate() error {
+	return Struct(r)
+}
+
+func TestValidateWrapperDoesNotRecurse(t *testing.T) {
+	err := testWrappedRequest{}.Validate()
+
+	got := strings.Join(errorFields(err), ",")
+	if got != "Name" {
+		t.Fatalf("fields = %s, want Name", got)
+	}
+
+	if err := (testWrappedRequest{Name: "Kevin"}).Validate(); err != nil {
+		t.Fatalf("expected valid request, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -11,59 +12,87 @@ import (
 	"unicode/utf8"
 )
 
-func (v *Validator) RegisterDefaults() {
-	v.Register("required", required)
-	v.Register("nonil", required)
-
-	v.Register("len", length)
-	v.Register("min", min)
-	v.Register("max", max)
-
-	v.Register("email", email)
-	v.Register("uuid", uuidV4)
-	v.Register("url", urlValue)
-
-	v.Register("ip", ip)
-	v.Register("ipv4", ipv4)
-	v.Register("ipv6", ipv6)
-
-	v.Register("alpha", alpha)
-	v.Register("alphanum", alphanum)
-	v.Register("num", numericString)
-	v.Register("decimal", decimalString)
+var (
+	emailRegex    = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+	uuidV4Regex   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+	alphaRegex    = regexp.MustCompile(`^[A-Za-zÁÉÍÓÚáéíóúÑñÜü]+$`)
+	alphanumRegex = regexp.MustCompile(`^[A-Za-z0-9ÁÉÍÓÚáéíóúÑñÜü]+$`)
+	numericRegex  = regexp.MustCompile(`^[0-9]+$`)
+	decimalRegex  = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+)
 
-	v.Register("lower", lower)
-	v.Register("upper", upper)
-	v.Register("oneof", oneOf)
+// valueValidators are the built-in rules that only read the value of the field
+var valueValidators = map[string]ValidatorFunc{
+	"required": required,
+	"nonil":    required,
+
+	"len": length,
+	"min": min,
+	"max": max,
+
+	"email": email,
+	"uuid":  uuidV4,
+	"url":   urlValue,
+
+	"ip":   ip,
+	"ipv4": ipv4,
+	"ipv6": ipv6,
+
+	"alpha":    alpha,
+	"alphanum": alphanum,
+	"num":      numericString,
+	"decimal":  decimalString,
+
+	"lower": lower,
+	"upper": upper,
+	"oneof": oneOf,
+
+	"prefix":   prefix,
+	"suffix":   suffix,
+	"contains": contains,
+
+	"date":     dateValue,
+	"time":     timeValue,
+	"datetime": dateTimeValue,
+	"utc":      utcValue,
+
+	"gt":  greaterThan,
+	"gte": greaterThanOrEqual,
+	"lt":  lessThan,
+	"lte": lessThanOrEqual,
+}
 
-	v.Register("prefix", prefix)
-	v.Register("suffix", suffix)
-	v.Register("contains", contains)
+func (v *Validator) RegisterDefaults() {
+	for name, fn := range valueValidators {
+		v.Register(name, fn)
+	}
 
-	v.Register("date", dateValue)
-	v.Register("time", timeValue)
-	v.Register("datetime", dateTimeValue)
-	v.Register("utc", utcValue)
+	v.registerCrossField()
+}
 
-	v.Register("gt", greaterThan)
-	v.Register("gte", greaterThanOrEqual)
-	v.Register("lt", lessThan)
-	v.Register("lte", lessThanOrEqual)
+// ValueRule reports whether name is a built-in rule that only reads the value
+// of the field, unlike the cross-field and custom rules. Code generated by
+// kcheckgen leaves Field.Parent and Field.Root empty when every rule of a field
+// is a value rule, so such names must not be registered again with validators
+// that read other fields.
+func ValueRule(name string) bool {
+	_, ok := valueValidators[name]
+	return ok || name == "dive" || name == "redact"
 }
 
 func required(f Field) error {
 	if f.IsNil {
-		return fmt.Errorf("el campo [%s] es requerido", f.Path)
+		return Fail(f, MsgRequired)
 	}
 
 	switch v := f.Value.(type) {
 	case string:
 		if strings.TrimSpace(v) == "" {
-			return fmt.Errorf("el campo [%s] es requerido", f.Path)
+			return Fail(f, MsgRequired)
 		}
-	case []string:
-		if len(v) == 0 {
-			return fmt.Errorf("el campo [%s] es requerido", f.Path)
+	default:
+		if n, ok := collectionLen(v); ok && n == 0 {
+			return Fail(f, MsgRequired)
 		}
 	}
 
@@ -73,16 +102,23 @@ func required(f Field) error {
 func length(f Field) error {
 	want, err := strconv.Atoi(f.Param)
 	if err != nil {
-		return fmt.Errorf("parámetro inválido para len en [%s]", f.Path)
+		return Fail(f, MsgInvalidLength)
 	}
 
 	switch v := f.Value.(type) {
 	case string:
 		if utf8.RuneCountInString(v) != want {
-			return fmt.Errorf("el campo [%s] debe tener longitud [%d]", f.Path, want)
+			return Fail(f, MsgLenString)
 		}
 	default:
-		return fmt.Errorf("len solo soporta string en [%s]", f.Path)
+		n, ok := collectionLen(v)
+		if !ok {
+			return Fail(f, MsgUnsupportedString)
+		}
+
+		if n != want {
+			return Fail(f, MsgLenItems)
+		}
 	}
 
 	return nil
@@ -91,23 +127,30 @@ func length(f Field) error {
 func min(f Field) error {
 	minVal, err := strconv.ParseFloat(f.Param, 64)
 	if err != nil {
-		return fmt.Errorf("parámetro inválido para min en [%s]", f.Path)
+		return Fail(f, MsgInvalidLength)
 	}
 
 	if s, ok := f.Value.(string); ok {
 		if utf8.RuneCountInString(s) < int(minVal) {
-			return fmt.Errorf("el campo [%s] debe tener mínimo [%d] caracteres", f.Path, int(minVal))
+			return Fail(f, MsgMinString)
+		}
+		return nil
+	}
+
+	if n, ok := collectionLen(f.Value); ok {
+		if n < int(minVal) {
+			return Fail(f, MsgMinItems)
 		}
 		return nil
 	}
 
 	num, ok := asFloat(f.Value)
 	if !ok {
-		return fmt.Errorf("min solo soporta string o número en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedStringNumber)
 	}
 
 	if num < minVal {
-		return fmt.Errorf("el campo [%s] debe ser mayor o igual a [%s]", f.Path, f.Param)
+		return Fail(f, MsgMinNumber)
 	}
 
 	return nil
@@ -116,23 +159,30 @@ func min(f Field) error {
 func max(f Field) error {
 	maxVal, err := strconv.ParseFloat(f.Param, 64)
 	if err != nil {
-		return fmt.Errorf("parámetro inválido para max en [%s]", f.Path)
+		return Fail(f, MsgInvalidLength)
 	}
 
 	if s, ok := f.Value.(string); ok {
 		if utf8.RuneCountInString(s) > int(maxVal) {
-			return fmt.Errorf("el campo [%s] debe tener máximo [%d] caracteres", f.Path, int(maxVal))
+			return Fail(f, MsgMaxString)
+		}
+		return nil
+	}
+
+	if n, ok := collectionLen(f.Value); ok {
+		if n > int(maxVal) {
+			return Fail(f, MsgMaxItems)
 		}
 		return nil
 	}
 
 	num, ok := asFloat(f.Value)
 	if !ok {
-		return fmt.Errorf("max solo soporta string o número en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedStringNumber)
 	}
 
 	if num > maxVal {
-		return fmt.Errorf("el campo [%s] debe ser menor o igual a [%s]", f.Path, f.Param)
+		return Fail(f, MsgMaxNumber)
 	}
 
 	return nil
@@ -141,12 +191,11 @@ func max(f Field) error {
 func email(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("email solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
-	rx := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
-	if !rx.MatchString(s) {
-		return fmt.Errorf("el campo [%s] no es un correo válido", f.Path)
+	if !emailRegex.MatchString(s) {
+		return Fail(f, MsgEmail)
 	}
 
 	return nil
@@ -155,12 +204,11 @@ func email(f Field) error {
 func uuidV4(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("uuid solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
-	rx := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
-	if !rx.MatchString(s) {
-		return fmt.Errorf("el campo [%s] debe ser un UUID v4 válido", f.Path)
+	if !uuidV4Regex.MatchString(s) {
+		return Fail(f, MsgUUID)
 	}
 
 	return nil
@@ -169,12 +217,12 @@ func uuidV4(f Field) error {
 func urlValue(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("url solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	u, err := url.ParseRequestURI(s)
 	if err != nil || u.Scheme == "" || u.Host == "" {
-		return fmt.Errorf("el campo [%s] debe ser una URL válida", f.Path)
+		return Fail(f, MsgURL)
 	}
 
 	return nil
@@ -183,11 +231,11 @@ func urlValue(f Field) error {
 func ip(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("ip solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if net.ParseIP(s) == nil {
-		return fmt.Errorf("el campo [%s] debe ser una IP válida", f.Path)
+		return Fail(f, MsgIP)
 	}
 
 	return nil
@@ -196,12 +244,12 @@ func ip(f Field) error {
 func ipv4(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("ipv4 solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	parsed := net.ParseIP(s)
 	if parsed == nil || parsed.To4() == nil {
-		return fmt.Errorf("el campo [%s] debe ser una IPv4 válida", f.Path)
+		return Fail(f, MsgIPv4)
 	}
 
 	return nil
@@ -210,12 +258,12 @@ func ipv4(f Field) error {
 func ipv6(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("ipv6 solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	parsed := net.ParseIP(s)
 	if parsed == nil || parsed.To4() != nil {
-		return fmt.Errorf("el campo [%s] debe ser una IPv6 válida", f.Path)
+		return Fail(f, MsgIPv6)
 	}
 
 	return nil
@@ -224,12 +272,11 @@ func ipv6(f Field) error {
 func alpha(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("alpha solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
-	rx := regexp.MustCompile(`^[A-Za-zÁÉÍÓÚáéíóúÑñÜü]+$`)
-	if !rx.MatchString(s) {
-		return fmt.Errorf("el campo [%s] solo debe contener letras", f.Path)
+	if !alphaRegex.MatchString(s) {
+		return Fail(f, MsgAlpha)
 	}
 
 	return nil
@@ -238,12 +285,11 @@ func alpha(f Field) error {
 func alphanum(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("alphanum solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
-	rx := regexp.MustCompile(`^[A-Za-z0-9ÁÉÍÓÚáéíóúÑñÜü]+$`)
-	if !rx.MatchString(s) {
-		return fmt.Errorf("el campo [%s] solo debe contener letras y números", f.Path)
+	if !alphanumRegex.MatchString(s) {
+		return Fail(f, MsgAlphanum)
 	}
 
 	return nil
@@ -252,12 +298,11 @@ func alphanum(f Field) error {
 func numericString(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("num solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
-	rx := regexp.MustCompile(`^[0-9]+$`)
-	if !rx.MatchString(s) {
-		return fmt.Errorf("el campo [%s] solo debe contener números", f.Path)
+	if !numericRegex.MatchString(s) {
+		return Fail(f, MsgNum)
 	}
 
 	return nil
@@ -266,12 +311,11 @@ func numericString(f Field) error {
 func decimalString(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("decimal solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
-	rx := regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
-	if !rx.MatchString(s) {
-		return fmt.Errorf("el campo [%s] debe ser decimal", f.Path)
+	if !decimalRegex.MatchString(s) {
+		return Fail(f, MsgDecimal)
 	}
 
 	return nil
@@ -280,11 +324,11 @@ func decimalString(f Field) error {
 func lower(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("lower solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if s != strings.ToLower(s) {
-		return fmt.Errorf("el campo [%s] debe estar en minúsculas", f.Path)
+		return Fail(f, MsgLower)
 	}
 
 	return nil
@@ -293,11 +337,11 @@ func lower(f Field) error {
 func upper(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("upper solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if s != strings.ToUpper(s) {
-		return fmt.Errorf("el campo [%s] debe estar en mayúsculas", f.Path)
+		return Fail(f, MsgUpper)
 	}
 
 	return nil
@@ -305,7 +349,7 @@ func upper(f Field) error {
 
 func oneOf(f Field) error {
 	if strings.TrimSpace(f.Param) == "" {
-		return fmt.Errorf("oneof requiere valores en [%s]", f.Path)
+		return Fail(f, MsgInvalidOneOf)
 	}
 
 	current := fmt.Sprint(f.Value)
@@ -316,17 +360,17 @@ func oneOf(f Field) error {
 		}
 	}
 
-	return fmt.Errorf("el campo [%s] debe ser uno de: [%s]", f.Path, f.Param)
+	return Fail(f, MsgOneOf)
 }
 
 func prefix(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("prefix solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if !strings.HasPrefix(s, f.Param) {
-		return fmt.Errorf("el campo [%s] debe empezar con [%s]", f.Path, f.Param)
+		return Fail(f, MsgPrefix)
 	}
 
 	return nil
@@ -335,11 +379,11 @@ func prefix(f Field) error {
 func suffix(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("suffix solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if !strings.HasSuffix(s, f.Param) {
-		return fmt.Errorf("el campo [%s] debe terminar con [%s]", f.Path, f.Param)
+		return Fail(f, MsgSuffix)
 	}
 
 	return nil
@@ -348,11 +392,11 @@ func suffix(f Field) error {
 func contains(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("contains solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if !strings.Contains(s, f.Param) {
-		return fmt.Errorf("el campo [%s] debe contener [%s]", f.Path, f.Param)
+		return Fail(f, MsgContains)
 	}
 
 	return nil
@@ -362,12 +406,12 @@ func dateValue(f Field) error {
 	switch v := f.Value.(type) {
 	case string:
 		if _, err := time.Parse(time.DateOnly, v); err != nil {
-			return fmt.Errorf("el campo [%s] debe ser una fecha válida", f.Path)
+			return Fail(f, MsgDate)
 		}
 	case time.Time:
 		return nil
 	default:
-		return fmt.Errorf("date solo soporta string o time.Time en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedStringTime)
 	}
 
 	return nil
@@ -376,11 +420,11 @@ func dateValue(f Field) error {
 func timeValue(f Field) error {
 	s, ok := f.Value.(string)
 	if !ok {
-		return fmt.Errorf("time solo soporta string en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedString)
 	}
 
 	if _, err := time.Parse(time.TimeOnly, s); err != nil {
-		return fmt.Errorf("el campo [%s] debe ser una hora válida", f.Path)
+		return Fail(f, MsgTime)
 	}
 
 	return nil
@@ -390,12 +434,12 @@ func dateTimeValue(f Field) error {
 	switch v := f.Value.(type) {
 	case string:
 		if _, err := time.Parse(time.DateTime, v); err != nil {
-			return fmt.Errorf("el campo [%s] debe ser fecha y hora válida", f.Path)
+			return Fail(f, MsgDateTime)
 		}
 	case time.Time:
 		return nil
 	default:
-		return fmt.Errorf("datetime solo soporta string o time.Time en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedStringTime)
 	}
 
 	return nil
@@ -406,59 +450,71 @@ func utcValue(f Field) error {
 	case string:
 		t, err := time.Parse(time.RFC3339, v)
 		if err != nil {
-			return fmt.Errorf("el campo [%s] debe tener formato UTC RFC3339", f.Path)
+			return Fail(f, MsgUTCFormat)
 		}
 
 		if t.Location() != time.UTC {
-			return fmt.Errorf("el campo [%s] debe estar en UTC", f.Path)
+			return Fail(f, MsgUTC)
 		}
 
 	case time.Time:
 		if v.Location() != time.UTC {
-			return fmt.Errorf("el campo [%s] debe estar en UTC", f.Path)
+			return Fail(f, MsgUTC)
 		}
 
 	default:
-		return fmt.Errorf("utc solo soporta string o time.Time en [%s]", f.Path)
+		return Fail(f, MsgUnsupportedStringTime)
 	}
 
 	return nil
 }
 
 func greaterThan(f Field) error {
-	return compareNumber(f, func(a, b float64) bool { return a > b }, "mayor que")
+	return compareNumber(f, func(a, b float64) bool { return a > b }, MsgGt)
 }
 
 func greaterThanOrEqual(f Field) error {
-	return compareNumber(f, func(a, b float64) bool { return a >= b }, "mayor o igual que")
+	return compareNumber(f, func(a, b float64) bool { return a >= b }, MsgGte)
 }
 
 func lessThan(f Field) error {
-	return compareNumber(f, func(a, b float64) bool { return a < b }, "menor que")
+	return compareNumber(f, func(a, b float64) bool { return a < b }, MsgLt)
 }
 
 func lessThanOrEqual(f Field) error {
-	return compareNumber(f, func(a, b float64) bool { return a <= b }, "menor o igual que")
+	return compareNumber(f, func(a, b float64) bool { return a <= b }, MsgLte)
 }
 
-func compareNumber(f Field, cmp func(float64, float64) bool, label string) error {
+func compareNumber(f Field, cmp func(float64, float64) bool, key string) error {
 	value, ok := asFloat(f.Value)
 	if !ok {
-		return fmt.Errorf("[%s] solo soporta números", f.Tag)
+		return Fail(f, MsgUnsupportedNumber)
 	}
 
 	param, err := strconv.ParseFloat(f.Param, 64)
 	if err != nil {
-		return fmt.Errorf("parámetro inválido para [%s] en [%s]", f.Tag, f.Path)
+		return Fail(f, MsgInvalidParam)
 	}
 
 	if !cmp(value, param) {
-		return fmt.Errorf("el campo [%s] debe ser %s [%s]", f.Path, label, f.Param)
+		return Fail(f, key)
 	}
 
 	return nil
 }
 
+// collectionLen returns the number of elements of a slice, array or map
+func collectionLen(v any) (int, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	default:
+		return 0, false
+	}
+}
+
 func asFloat(v any) (float64, bool) {
 	switch n := v.(type) {
 	case int:
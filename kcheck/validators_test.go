@@ -547,3 +547,25 @@ func TestValidatorCustomRegister(t *testing.T) {
 		t.Fatal("expected custom validator error")
 	}
 }
+
+func TestValidatorsCollections(t *testing.T) {
+	if err := required(Field{Path: "Items", Value: []int{}}); err == nil {
+		t.Fatal("expected required error for empty slice")
+	}
+
+	if err := required(Field{Path: "Meta", Value: map[string]int{"a": 1}}); err != nil {
+		t.Fatalf("expected valid required map, got %v", err)
+	}
+
+	if err := length(Field{Path: "Items", Value: []int{1, 2}, Param: "2"}); err != nil {
+		t.Fatalf("expected valid len slice, got %v", err)
+	}
+
+	if err := min(Field{Path: "Items", Value: []int{1}, Param: "2"}); err == nil {
+		t.Fatal("expected min slice error")
+	}
+
+	if err := max(Field{Path: "Meta", Value: map[string]int{"a": 1, "b": 2}, Param: "1"}); err == nil {
+		t.Fatal("expected max map error")
+	}
+}
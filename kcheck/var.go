@@ -0,0 +1,41 @@
+package kcheck
+
+import "reflect"
+
+// DefaultVarName is the name reported by Var
+const DefaultVarName = "valor"
+
+// Var validates a value outside a struct with the rules of a chk tag,
+// e.g. v.Var(id, "required uuid") or v.Var(emails, "max=3 dive email").
+// Context validators run with context.Background().
+func (v *Validator) Var(value any, rules string) error {
+	return v.VarWithName(DefaultVarName, value, rules)
+}
+
+// VarWithName is Var reporting the errors under name, e.g. the query param
+// being validated. Elements are reported as name[0], name[key].
+func (v *Validator) VarWithName(name string, value any, rules string) error {
+	rv := reflect.ValueOf(value)
+	opts := options{root: rv, redact: v.redactValues()}
+
+	var errs Errors
+	v.validateValue(rv, reflect.Value{}, name, name, v.varRules(rules), opts, &errs)
+
+	return errs.Err()
+}
+
+// varRules parses and binds the rules of Var. They are cached per rules string
+// and dropped by resetPlans.
+func (v *Validator) varRules(tag string) []rule {
+	if cached, ok := v.vars.Load(tag); ok {
+		return cached.([]rule)
+	}
+
+	rules := ParseTag(tag, "")
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	cached, _ := v.vars.LoadOrStore(tag, v.resolveRules(rules))
+	return cached.([]rule)
+}
@@ -0,0 +1,122 @@
+package kcheck
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVar(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		rules  string
+		fields []string
+		codes  []string
+	}{
+		{name: "uuid válido", value: "1b4e28ba-2fa1-41d2-883f-0016d3cca427", rules: "required uuid"},
+		{name: "uuid inválido", value: "abc", rules: "required uuid", fields: []string{"valor"}, codes: []string{"UUID"}},
+		{name: "vacío", value: "", rules: "required uuid", fields: []string{"valor", "valor"}, codes: []string{"REQUIRED", "UUID"}},
+		{name: "nil", value: nil, rules: "required", fields: []string{"valor"}, codes: []string{"REQUIRED"}},
+		{name: "puntero", value: strPtr("ab"), rules: "min=3", fields: []string{"valor"}, codes: []string{"MIN"}},
+		{name: "número", value: 150, rules: "gte=1 lte=100", fields: []string{"valor"}, codes: []string{"LTE"}},
+		{name: "regla desconocida", value: "x", rules: "startsx", fields: []string{"valor"}, codes: []string{CodeUnknownRule}},
+		{
+			name:   "slice con dive",
+			value:  []string{"a@b.com", "mal", ""},
+			rules:  "max=2 dive required email",
+			fields: []string{"valor", "valor[1]", "valor[2]", "valor[2]"},
+			codes:  []string{"MAX", "EMAIL", "REQUIRED", "EMAIL"},
+		},
+		{
+			name:   "map con dive",
+			value:  map[string]int{"b": 0, "a": 5},
+			rules:  "dive gt=0",
+			fields: []string{"valor[b]"},
+			codes:  []string{"GT"},
+		},
+		{
+			name:   "slice de structs",
+			value:  []testAddress{{City: "Lima"}, {City: "X"}},
+			rules:  "min=1",
+			fields: []string{"valor[1].City"},
+			codes:  []string{"MIN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Var(tt.value, tt.rules)
+
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected Errors, got %v", err)
+			}
+
+			var fields, codes []string
+			for _, item := range errs.Items {
+				fields = append(fields, item.Field)
+				codes = append(codes, item.Code)
+			}
+
+			if !reflect.DeepEqual(fields, tt.fields) || !reflect.DeepEqual(codes, tt.codes) {
+				t.Fatalf("expected %v %v, got %v %v", tt.fields, tt.codes, fields, codes)
+			}
+		})
+	}
+}
+
+func TestVarWithName(t *testing.T) {
+	err := VarWithName("page_size", 500, "gte=1 lte=100")
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs.Items) != 1 {
+		t.Fatalf("expected one error, got %v", err)
+	}
+
+	item := errs.Items[0]
+	if item.Field != "page_size" || item.Rule != "lte" || item.Param != "100" || item.Value != RedactedValue {
+		t.Fatalf("unexpected error %+v", item)
+	}
+
+	if item.Message != "el campo [page_size] debe ser menor o igual que [100]" {
+		t.Fatalf("unexpected message %q", item.Message)
+	}
+}
+
+func TestVarCustomValidators(t *testing.T) {
+	v := New()
+
+	if err := v.Var("abc", "startsx"); err == nil {
+		t.Fatal("expected unknown rule error")
+	}
+
+	v.Register("startsx", func(f Field) error {
+		if f.Value != "x" {
+			return errors.New("debe ser x")
+		}
+		return nil
+	})
+	v.RegisterCtx("taken", func(ctx context.Context, f Field) error {
+		return errors.New("ya existe")
+	})
+
+	err := v.Var("abc", "startsx taken redact")
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs.Items) != 2 {
+		t.Fatalf("expected two errors after Register, got %v", err)
+	}
+
+	if errs.Items[0].Code != "STARTSX" || errs.Items[1].Code != "TAKEN" || errs.Items[1].Value != RedactedValue {
+		t.Fatalf("unexpected errors %+v", errs.Items)
+	}
+}